@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func (h *Handler) registerBudgetHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("POST /budgets", h.createBudgetHandler)
+	mux.HandleFunc("GET /budgets/{id}", h.getBudgetHandler)
+	mux.HandleFunc("GET /budgets/{id}/status", h.budgetStatusHandler)
+}
+
+func (h *Handler) createBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	var req budgetReq
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if err := validateBudget(req); err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	data := models.Budget{
+		TeamID:      req.TeamID,
+		Period:      req.Period,
+		Amount:      req.Amount,
+		Category:    strings.TrimSpace(req.Category),
+		ServiceName: strings.TrimSpace(req.ServiceName),
+	}
+	if req.UserID != nil {
+		userID := strings.ToLower(strings.TrimSpace(*req.UserID))
+		data.UserID = &userID
+	}
+
+	id, err := h.budgets.CreateBudget(r.Context(), data)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Ошибка CreateBudget()")
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusCreated, idRes{ID: id})
+}
+
+func (h *Handler) getBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	data, err := h.budgets.GetBudget(r.Context(), id)
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgBudgetNotFound, "Ошибка GetBudget()", zap.Int("id", id))
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, toBudgetRes(data))
+}
+
+func (h *Handler) budgetStatusHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	st, err := h.budgets.Status(r.Context(), id)
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgBudgetNotFound, "Ошибка Status()", zap.Int("id", id))
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, budgetStatusRes{
+		Budget:      toBudgetRes(st.Budget),
+		PeriodStart: st.PeriodStart.Format("01-2006"),
+		PeriodEnd:   st.PeriodEnd.Format("01-2006"),
+		Spent:       st.Spent,
+		Remaining:   st.Remaining,
+		Projected:   st.Projected,
+		PercentUsed: st.PercentUsed,
+	})
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+)
+
+func (h *Handler) writeProblem(w http.ResponseWriter, r *http.Request, p httpx.Problem) {
+	if err := httpx.WriteProblem(w, r, p); err != nil {
+		h.log(r.Context()).Warn("не удалось записать ответ об ошибке", zap.Error(err))
+	}
+}
+
+// writeError пишет ошибку с detail из каталога сообщений на языке запроса.
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, status int, code, detailKey string, args ...any) {
+	if err := httpx.WriteError(w, r, status, code, detailKey, args...); err != nil {
+		h.log(r.Context()).Warn("не удалось записать ответ об ошибке", zap.Error(err))
+	}
+}
+
+// writeServiceError сопоставляет ошибку сервиса с problem+json ответом.
+// Неизвестные ошибки логируются с msg и fields и отдаются клиенту как 500 без подробностей.
+func (h *Handler) writeServiceError(w http.ResponseWriter, r *http.Request, err error, msg string, fields ...zap.Field) {
+	var (
+		validationErr *services.ValidationError
+		overlapErr    *services.OverlapError
+	)
+
+	switch {
+	case errors.As(err, &validationErr):
+		h.writeValidationError(w, r, validationErr)
+	case errors.Is(err, services.ErrValidation):
+		h.writeError(w, r, http.StatusBadRequest, httpx.CodeValidationFailed, "")
+	case errors.As(err, &overlapErr):
+		ids := make([]string, 0, len(overlapErr.Overlaps))
+		for _, item := range overlapErr.Overlaps {
+			ids = append(ids, strconv.Itoa(item.ID))
+		}
+		h.writeError(w, r, http.StatusConflict, httpx.CodeSubscriptionOverlap, i18n.MsgSubscriptionOverlap, strings.Join(ids, ", "))
+	case errors.Is(err, services.ErrConflict):
+		h.writeError(w, r, http.StatusConflict, httpx.CodeConflict, "")
+	case errors.Is(err, services.ErrNotFound):
+		h.writeError(w, r, http.StatusNotFound, httpx.CodeNotFound, "")
+	case errors.Is(err, services.ErrForbidden):
+		h.writeError(w, r, http.StatusForbidden, httpx.CodeForbidden, i18n.MsgForbidden)
+	default:
+		h.log(r.Context()).Error(msg, append(fields, zap.Error(err))...)
+		h.writeError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "")
+	}
+}
+
+// writeNotFoundError - как writeServiceError, но 404 с detail по notFoundKey.
+func (h *Handler) writeNotFoundError(w http.ResponseWriter, r *http.Request, err error, notFoundKey, msg string, fields ...zap.Field) {
+	if errors.Is(err, services.ErrNotFound) {
+		h.writeError(w, r, http.StatusNotFound, httpx.CodeNotFound, notFoundKey)
+		return
+	}
+	h.writeServiceError(w, r, err, msg, fields...)
+}
+
+func (h *Handler) writeValidationError(w http.ResponseWriter, r *http.Request, err *services.ValidationError) {
+	lang := i18n.FromContext(r.Context())
+
+	p := httpx.NewProblem(http.StatusBadRequest, httpx.CodeValidationFailed, "")
+	p.Errors = make([]httpx.FieldError, 0, len(err.Fields))
+	for _, f := range err.Fields {
+		p.Errors = append(p.Errors, httpx.FieldError{Field: f.Field, Code: f.Code, Message: f.Message(lang)})
+	}
+	if len(p.Errors) == 1 {
+		p.Detail = p.Errors[0].Message
+	}
+	h.writeProblem(w, r, p)
+}
+
+// writeJSON пишет успешный ответ; ошибка сериализации превращается в 500,
+// обрыв соединения клиентом только логируется.
+func (h *Handler) writeJSON(w http.ResponseWriter, r *http.Request, code int, v any) {
+	h.handleWriteError(w, r, httpx.WriteJSON(w, code, v))
+}
+
+// writeConditionalJSON пишет ответ с ETag и Cache-Control, на совпадающий If-None-Match - 304.
+func (h *Handler) writeConditionalJSON(w http.ResponseWriter, r *http.Request, v any, lastModified time.Time) {
+	h.handleWriteError(w, r, httpx.WriteConditionalJSON(w, r, v, lastModified))
+}
+
+func (h *Handler) handleWriteError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case err == nil:
+	case errors.Is(err, httpx.ErrJSONMarshal):
+		h.log(r.Context()).Error("не удалось сериализовать JSON", zap.Error(err))
+		h.writeError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "")
+	case errors.Is(err, httpx.ErrWriteBody):
+		h.log(r.Context()).Warn("клиент закрыл соединение, ответ не был отправлен", zap.Error(err))
+	}
+}
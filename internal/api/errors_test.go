@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/api"
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/mocks"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func TestWriteServiceError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantFields int
+	}{
+		{
+			name:       "ошибка валидации по полям",
+			err:        services.NewValidationError(services.FieldError{Field: "months", Code: services.CodeOutOfRange, Key: i18n.MsgForecastMonths, Args: []any{services.MaxForecastMonths}}),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   httpx.CodeValidationFailed,
+			wantFields: 1,
+		},
+		{
+			name:       "ошибка валидации без полей",
+			err:        fmt.Errorf("%w: месяц", services.ErrValidation),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   httpx.CodeValidationFailed,
+		},
+		{
+			name:       "доступ запрещён",
+			err:        services.ErrForbidden,
+			wantStatus: http.StatusForbidden,
+			wantCode:   httpx.CodeForbidden,
+		},
+		{
+			name:       "не найдено",
+			err:        fmt.Errorf("service Forecast(): %w", services.ErrNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   httpx.CodeNotFound,
+		},
+		{
+			name:       "пересечение подписок",
+			err:        &services.OverlapError{Overlaps: []models.Subscription{{ID: 7}}},
+			wantStatus: http.StatusConflict,
+			wantCode:   httpx.CodeSubscriptionOverlap,
+		},
+		{
+			name:       "конфликт",
+			err:        fmt.Errorf("%w: дубликат", services.ErrConflict),
+			wantStatus: http.StatusConflict,
+			wantCode:   httpx.CodeConflict,
+		},
+		{
+			name:       "внутренняя ошибка",
+			err:        errors.New("connection reset"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   httpx.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mocks.NewSubscriptionService(t)
+			svc.EXPECT().Forecast(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+			mux := http.NewServeMux()
+			api.New(svc, zap.NewNop()).RegisterHandlers(mux)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/subscriptions/forecast", nil))
+
+			var p httpx.Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Equal(t, httpx.ProblemContentType, w.Header().Get("Content-Type"))
+			require.Equal(t, tt.wantCode, p.Code)
+			require.Equal(t, tt.wantStatus, p.Status)
+			require.Len(t, p.Errors, tt.wantFields)
+			require.NotContains(t, p.Detail, "connection reset")
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package api
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
 // Request модели
 type createSubscriptionReq struct {
 	ServiceName string `json:"service_name"`
@@ -7,6 +14,11 @@ type createSubscriptionReq struct {
 	UserID      string `json:"user_id"`
 	StartDate   string `json:"start_date"`
 	EndDate     string `json:"end_date,omitempty"`
+	TeamID      *int   `json:"team_id,omitempty"`
+	Category    string `json:"category,omitempty"`
+	// TrialEnd - последний бесплатный месяц; TrialMonths - то же числом месяцев от start_date.
+	TrialEnd    string `json:"trial_end,omitempty"`
+	TrialMonths *int   `json:"trial_months,omitempty"`
 }
 
 type updateSubscriptionReq struct {
@@ -15,14 +27,266 @@ type updateSubscriptionReq struct {
 	UserID      *string `json:"user_id,omitempty"`
 	StartDate   *string `json:"start_date,omitempty"`
 	EndDate     *string `json:"end_date,omitempty"`
+	// Category: пустая строка убирает категорию.
+	Category *string `json:"category,omitempty"`
+	// TrialEnd: пустая строка убирает пробный период. TrialMonths считается от итоговой start_date.
+	TrialEnd    *string `json:"trial_end,omitempty"`
+	TrialMonths *int    `json:"trial_months,omitempty"`
+	// TeamID: null открепляет подписку от команды.
+	TeamID optionalInt `json:"team_id"`
+}
+
+// optionalInt - поле PATCH, которое можно не передать, передать null или число.
+type optionalInt struct {
+	Set   bool
+	Value *int
+}
+
+func (o *optionalInt) UnmarshalJSON(b []byte) error {
+	o.Set = true
+	if string(b) == "null" {
+		o.Value = nil
+		return nil
+	}
+	var v int
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	o.Value = &v
+	return nil
 }
 
 // Response модели
 type subscriptionRes struct {
-	ID          int    `json:"id"`
-	ServiceName string `json:"service_name"`
-	Price       int    `json:"price"`
-	UserID      string `json:"user_id"`
-	StartDate   string `json:"start_date"`
-	EndDate     string `json:"end_date,omitempty"`
+	ID           int              `json:"id"`
+	ServiceName  string           `json:"service_name"`
+	Price        int              `json:"price"`
+	UserID       string           `json:"user_id"`
+	StartDate    string           `json:"start_date"`
+	EndDate      string           `json:"end_date,omitempty"`
+	TeamID       *int             `json:"team_id,omitempty"`
+	Category     string           `json:"category,omitempty"`
+	TrialEnd     string           `json:"trial_end,omitempty"`
+	Participants []participantRes `json:"participants,omitempty"`
+	PriceChanges []priceChangeRes `json:"price_changes,omitempty"`
+	Pauses       []pauseRes       `json:"pauses,omitempty"`
+	CreatedAt    string           `json:"created_at"`
+	UpdatedAt    string           `json:"updated_at"`
+}
+
+type participantReq struct {
+	ShareWeight *int `json:"share_weight,omitempty"`
+	FixedAmount *int `json:"fixed_amount,omitempty"`
+}
+
+// participantRes - участник и его доля цены в месяц (monthly_share).
+type participantRes struct {
+	UserID       string `json:"user_id"`
+	ShareWeight  *int   `json:"share_weight,omitempty"`
+	FixedAmount  *int   `json:"fixed_amount,omitempty"`
+	MonthlyShare int    `json:"monthly_share"`
+}
+
+type priceChangeReq struct {
+	Price *int `json:"price"`
+}
+
+type priceChangeRes struct {
+	EffectiveFrom string `json:"effective_from"`
+	Price         int    `json:"price"`
+}
+
+// pauseReq: From по умолчанию - текущий месяц, без Until пауза бессрочная.
+type pauseReq struct {
+	From  string `json:"from,omitempty"`
+	Until string `json:"until,omitempty"`
+}
+
+// resumeReq: From - первый оплачиваемый месяц после паузы, по умолчанию текущий.
+type resumeReq struct {
+	From string `json:"from,omitempty"`
+}
+
+type pauseRes struct {
+	From  string `json:"from"`
+	Until string `json:"until,omitempty"`
+}
+
+type forecastRes struct {
+	Months    []monthCostRes `json:"months"`
+	TotalCost int            `json:"total_cost"`
+}
+
+type monthCostRes struct {
+	Month     string `json:"month"`
+	TotalCost int    `json:"total_cost"`
+}
+
+type createSubscriptionRes struct {
+	ID       int   `json:"id"`
+	Overlaps []int `json:"overlaps,omitempty"`
+}
+
+type duplicateGroupRes struct {
+	UserID        string            `json:"user_id"`
+	ServiceName   string            `json:"service_name"`
+	Subscriptions []subscriptionRes `json:"subscriptions"`
+}
+
+func toSubscriptionRes(dataItem models.Subscription) subscriptionRes {
+	res := subscriptionRes{
+		ID:          dataItem.ID,
+		ServiceName: dataItem.ServiceName,
+		Price:       dataItem.Price,
+		UserID:      dataItem.UserID,
+		StartDate:   dataItem.StartDate.Local().Format("01-2006"),
+		TeamID:      dataItem.TeamID,
+		Category:    dataItem.Category,
+		CreatedAt:   dataItem.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:   dataItem.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if dataItem.EndDate != nil {
+		res.EndDate = dataItem.EndDate.Local().Format("01-2006")
+	}
+	if dataItem.TrialEnd != nil {
+		res.TrialEnd = dataItem.TrialEnd.Local().Format("01-2006")
+	}
+	res.Participants = toParticipantsRes(dataItem)
+	for _, p := range dataItem.Pauses {
+		pause := pauseRes{From: p.Start.Local().Format("01-2006")}
+		if p.End != nil {
+			pause.Until = p.End.Local().Format("01-2006")
+		}
+		res.Pauses = append(res.Pauses, pause)
+	}
+	for _, c := range dataItem.PriceChanges {
+		res.PriceChanges = append(res.PriceChanges, priceChangeRes{
+			EffectiveFrom: c.EffectiveFrom.Local().Format("01-2006"),
+			Price:         c.Price,
+		})
+	}
+	return res
+}
+
+func toParticipantsRes(dataItem models.Subscription) []participantRes {
+	if len(dataItem.Participants) == 0 {
+		return nil
+	}
+	shares := dataItem.Shares()
+	res := make([]participantRes, 0, len(dataItem.Participants))
+	for _, p := range dataItem.Participants {
+		res = append(res, participantRes{
+			UserID:       p.UserID,
+			ShareWeight:  p.ShareWeight,
+			FixedAmount:  p.FixedAmount,
+			MonthlyShare: shares[p.UserID],
+		})
+	}
+	return res
+}
+
+type budgetReq struct {
+	UserID      *string `json:"user_id,omitempty"`
+	TeamID      *int    `json:"team_id,omitempty"`
+	Period      string  `json:"period"`
+	Amount      int     `json:"amount"`
+	Category    string  `json:"category,omitempty"`
+	ServiceName string  `json:"service_name,omitempty"`
+}
+
+type organizationReq struct {
+	Name string `json:"name"`
+}
+
+type teamReq struct {
+	Name string `json:"name"`
+}
+
+type memberReq struct {
+	Role string `json:"role,omitempty"`
+}
+
+type idRes struct {
+	ID int `json:"id"`
+}
+
+type organizationRes struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	OwnerID   *string `json:"owner_id,omitempty"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type teamRes struct {
+	ID             int    `json:"id"`
+	OrganizationID int    `json:"organization_id"`
+	Name           string `json:"name"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type memberRes struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type teamCostRes struct {
+	TeamID    *int `json:"team_id"`
+	TotalCost int  `json:"total_cost"`
+}
+
+type totalCostByTeamRes struct {
+	TotalCost int           `json:"total_cost"`
+	Teams     []teamCostRes `json:"teams"`
+}
+
+type budgetRes struct {
+	ID          int     `json:"id"`
+	UserID      *string `json:"user_id,omitempty"`
+	TeamID      *int    `json:"team_id,omitempty"`
+	Period      string  `json:"period"`
+	Amount      int     `json:"amount"`
+	Category    string  `json:"category,omitempty"`
+	ServiceName string  `json:"service_name,omitempty"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+type budgetStatusRes struct {
+	Budget      budgetRes `json:"budget"`
+	PeriodStart string    `json:"period_start"`
+	PeriodEnd   string    `json:"period_end"`
+	Spent       int       `json:"spent"`
+	Remaining   int       `json:"remaining"`
+	Projected   int       `json:"projected"`
+	PercentUsed int       `json:"percent_used"`
+}
+
+func toBudgetRes(data models.Budget) budgetRes {
+	return budgetRes{
+		ID:          data.ID,
+		UserID:      data.UserID,
+		TeamID:      data.TeamID,
+		Period:      data.Period,
+		Amount:      data.Amount,
+		Category:    data.Category,
+		ServiceName: data.ServiceName,
+		CreatedAt:   data.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func toOrganizationRes(data models.Organization) organizationRes {
+	return organizationRes{
+		ID:        data.ID,
+		Name:      data.Name,
+		OwnerID:   data.OwnerID,
+		CreatedAt: data.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func toTeamRes(data models.Team) teamRes {
+	return teamRes{
+		ID:             data.ID,
+		OrganizationID: data.OrganizationID,
+		Name:           data.Name,
+		CreatedAt:      data.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
 }
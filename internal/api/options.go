@@ -0,0 +1,26 @@
+package api
+
+import "github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+
+type Option func(*Handler)
+
+// WithOverlapMode задаёт режим проверки пересечений по умолчанию (если не передан ?on_overlap).
+func WithOverlapMode(mode services.OverlapMode) Option {
+	return func(h *Handler) {
+		h.overlapMode = mode
+	}
+}
+
+// WithOrganizations включает API организаций и команд.
+func WithOrganizations(svc services.OrganizationService) Option {
+	return func(h *Handler) {
+		h.orgs = svc
+	}
+}
+
+// WithBudgets включает API бюджетов.
+func WithBudgets(svc services.BudgetService) Option {
+	return func(h *Handler) {
+		h.budgets = svc
+	}
+}
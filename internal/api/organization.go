@@ -0,0 +1,197 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func (h *Handler) registerOrganizationHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("POST /organizations", h.createOrganizationHandler)
+	mux.HandleFunc("GET /organizations/{id}", h.getOrganizationHandler)
+	mux.HandleFunc("POST /organizations/{id}/teams", h.createTeamHandler)
+	mux.HandleFunc("GET /organizations/{id}/teams", h.listTeamsHandler)
+	mux.HandleFunc("GET /teams/{id}", h.getTeamHandler)
+	mux.HandleFunc("GET /teams/{id}/members", h.listMembersHandler)
+	mux.HandleFunc("PUT /teams/{id}/members/{user_id}", h.setMemberHandler)
+	mux.HandleFunc("DELETE /teams/{id}/members/{user_id}", h.removeMemberHandler)
+}
+
+func (h *Handler) createOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	var req organizationReq
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if err := validateName(req.Name); err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	id, err := h.orgs.CreateOrganization(r.Context(), models.Organization{Name: strings.TrimSpace(req.Name)})
+	if err != nil {
+		h.writeServiceError(w, r, err, "Ошибка CreateOrganization()")
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusCreated, idRes{ID: id})
+}
+
+func (h *Handler) getOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	org, err := h.orgs.GetOrganization(r.Context(), id)
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgOrganizationNotFound, "Ошибка GetOrganization()", zap.Int("id", id))
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, toOrganizationRes(org))
+}
+
+func (h *Handler) createTeamHandler(w http.ResponseWriter, r *http.Request) {
+	orgID, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	var req teamReq
+	if !h.decode(w, r, &req) {
+		return
+	}
+	if err := validateName(req.Name); err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	id, err := h.orgs.CreateTeam(r.Context(), models.Team{OrganizationID: orgID, Name: strings.TrimSpace(req.Name)})
+	if err != nil {
+		h.writeServiceError(w, r, err, "Ошибка CreateTeam()", zap.Int("organization_id", orgID))
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusCreated, idRes{ID: id})
+}
+
+func (h *Handler) listTeamsHandler(w http.ResponseWriter, r *http.Request) {
+	orgID, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	teams, err := h.orgs.ListTeams(r.Context(), orgID)
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgOrganizationNotFound, "Ошибка ListTeams()", zap.Int("organization_id", orgID))
+		return
+	}
+
+	resp := make([]teamRes, 0, len(teams))
+	for _, t := range teams {
+		resp = append(resp, toTeamRes(t))
+	}
+	h.writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (h *Handler) getTeamHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	team, err := h.orgs.GetTeam(r.Context(), id)
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgTeamNotFound, "Ошибка GetTeam()", zap.Int("id", id))
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, toTeamRes(team))
+}
+
+func (h *Handler) listMembersHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	members, err := h.orgs.ListMembers(r.Context(), id)
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgTeamNotFound, "Ошибка ListMembers()", zap.Int("id", id))
+		return
+	}
+
+	resp := make([]memberRes, 0, len(members))
+	for _, m := range members {
+		resp = append(resp, memberRes{UserID: m.UserID, Role: m.Role})
+	}
+	h.writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (h *Handler) setMemberHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	var req memberReq
+	if r.ContentLength != 0 && !h.decode(w, r, &req) {
+		return
+	}
+	userID := strings.ToLower(strings.TrimSpace(r.PathValue("user_id")))
+	if err := validateMember(userID, req); err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	err = h.orgs.SetMember(r.Context(), models.TeamMember{TeamID: id, UserID: userID, Role: req.Role})
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgTeamNotFound, "Ошибка SetMember()", zap.Int("id", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) removeMemberHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+	userID := strings.ToLower(strings.TrimSpace(r.PathValue("user_id")))
+	if err := validateMember(userID, memberReq{}); err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	if err := h.orgs.RemoveMember(r.Context(), id, userID); err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgMemberNotFound, "Ошибка RemoveMember()", zap.Int("id", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decode читает JSON тело запроса; при ошибке пишет 400 и возвращает false.
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, dst any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, httpx.CodeInvalidJSON, "")
+		return false
+	}
+	return true
+}
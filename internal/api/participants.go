@@ -0,0 +1,129 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func (h *Handler) listParticipantsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	dataItem, err := h.svc.GetByID(r.Context(), id)
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgSubscriptionNotFound, "Ошибка GetByID()", zap.Int("id", id))
+		return
+	}
+
+	resp := toParticipantsRes(dataItem)
+	if resp == nil {
+		resp = []participantRes{}
+	}
+	h.writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (h *Handler) setParticipantHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	var req participantReq
+	if !h.decode(w, r, &req) {
+		return
+	}
+	userID := strings.ToLower(strings.TrimSpace(r.PathValue("user_id")))
+	if err := validateParticipant(userID, req); err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	err = h.svc.SetParticipant(r.Context(), id, models.Participant{
+		UserID:      userID,
+		ShareWeight: req.ShareWeight,
+		FixedAmount: req.FixedAmount,
+	})
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgSubscriptionNotFound, "Ошибка SetParticipant()", zap.Int("id", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) removeParticipantHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+	userID := strings.ToLower(strings.TrimSpace(r.PathValue("user_id")))
+	if !validUUID(userID) {
+		h.writeServiceError(w, r, fieldError("user_id", services.CodeInvalidFormat, i18n.MsgFieldUUID, "user_id"), "ошибка валидации запроса")
+		return
+	}
+
+	if err := h.svc.RemoveParticipant(r.Context(), id, userID); err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgParticipantNotFound, "Ошибка RemoveParticipant()", zap.Int("id", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) setPriceChangeHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	var req priceChangeReq
+	if !h.decode(w, r, &req) {
+		return
+	}
+	effectiveFrom, err := validatePriceChange(r.PathValue("month"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	err = h.svc.SetPriceChange(r.Context(), id, models.PriceChange{EffectiveFrom: effectiveFrom, Price: *req.Price})
+	if err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgSubscriptionNotFound, "Ошибка SetPriceChange()", zap.Int("id", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) removePriceChangeHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+	effectiveFrom, err := time.Parse("01-2006", strings.TrimSpace(r.PathValue("month")))
+	if err != nil {
+		h.writeServiceError(w, r, fieldError("effective_from", services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, "effective_from"), "ошибка валидации запроса")
+		return
+	}
+
+	if err := h.svc.RemovePriceChange(r.Context(), id, effectiveFrom); err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgPriceChangeNotFound, "Ошибка RemovePriceChange()", zap.Int("id", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
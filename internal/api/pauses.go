@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func (h *Handler) pauseHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	var req pauseReq
+	if r.ContentLength != 0 && !h.decode(w, r, &req) {
+		return
+	}
+	from, until, err := validatePause(req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+	if err := h.svc.Pause(r.Context(), id, models.Pause{Start: from, End: until}); err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgSubscriptionNotFound, "Ошибка Pause()", zap.Int("id", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) resumeHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	var req resumeReq
+	if r.ContentLength != 0 && !h.decode(w, r, &req) {
+		return
+	}
+	var errs fieldErrors
+	from := parseOptionalMonth(req.From, "from", &errs)
+	if err := errs.err(); err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+	if err := h.svc.Resume(r.Context(), id, from); err != nil {
+		h.writeNotFoundError(w, r, err, i18n.MsgSubscriptionNotFound, "Ошибка Resume()", zap.Int("id", id))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
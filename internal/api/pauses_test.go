@@ -0,0 +1,45 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/api"
+	"github.com/sunr3d/subscription-aggregator/internal/middleware"
+	"github.com/sunr3d/subscription-aggregator/mocks"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func newPauseServer(t *testing.T) (http.Handler, *mocks.SubscriptionService) {
+	t.Helper()
+	svc := mocks.NewSubscriptionService(t)
+	mux := http.NewServeMux()
+	api.New(svc, zap.NewNop()).RegisterHandlers(mux)
+	return middleware.JSONValidator(zap.NewNop())(mux), svc
+}
+
+func TestResume_WithoutBody(t *testing.T) {
+	srv, svc := newPauseServer(t)
+	svc.EXPECT().Resume(mock.Anything, 1, time.Time{}).Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/subscriptions/1/resume", nil))
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestPause_WithoutBody(t *testing.T) {
+	srv, svc := newPauseServer(t)
+	svc.EXPECT().Pause(mock.Anything, 1, models.Pause{}).Return(nil)
+
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/subscriptions/1/pause", nil))
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
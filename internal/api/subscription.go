@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -11,20 +12,38 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
 	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/logger"
 	"github.com/sunr3d/subscription-aggregator/models"
 )
 
+// overlapsHeader - id пересекающихся подписок в режиме on_overlap=warn.
+const overlapsHeader = "X-Overlapping-Subscriptions"
+
 type Handler struct {
-	svc    services.SubscriptionService
-	logger *zap.Logger
+	svc         services.SubscriptionService
+	orgs        services.OrganizationService
+	budgets     services.BudgetService
+	logger      *zap.Logger
+	overlapMode services.OverlapMode
 }
 
-func New(svc services.SubscriptionService, logger *zap.Logger) *Handler {
-	return &Handler{
-		svc:    svc,
-		logger: logger,
+func New(svc services.SubscriptionService, logger *zap.Logger, opts ...Option) *Handler {
+	h := &Handler{
+		svc:         svc,
+		logger:      logger,
+		overlapMode: services.OverlapWarn,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
+}
+
+// log возвращает логгер запроса (с request_id и trace_id), если он есть в контексте.
+func (h *Handler) log(ctx context.Context) *zap.Logger {
+	return logger.FromContext(ctx, h.logger)
 }
 
 func (h *Handler) RegisterHandlers(mux *http.ServeMux) {
@@ -34,6 +53,22 @@ func (h *Handler) RegisterHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("DELETE /subscriptions/{id}", h.deleteHandler)
 	mux.HandleFunc("GET /subscriptions", h.listHandler)
 	mux.HandleFunc("GET /subscriptions/total", h.totalCostHandler)
+	mux.HandleFunc("GET /subscriptions/duplicates", h.duplicatesHandler)
+	mux.HandleFunc("GET /subscriptions/forecast", h.forecastHandler)
+	mux.HandleFunc("GET /subscriptions/{id}/participants", h.listParticipantsHandler)
+	mux.HandleFunc("PUT /subscriptions/{id}/participants/{user_id}", h.setParticipantHandler)
+	mux.HandleFunc("DELETE /subscriptions/{id}/participants/{user_id}", h.removeParticipantHandler)
+	mux.HandleFunc("PUT /subscriptions/{id}/price-changes/{month}", h.setPriceChangeHandler)
+	mux.HandleFunc("DELETE /subscriptions/{id}/price-changes/{month}", h.removePriceChangeHandler)
+	mux.HandleFunc("POST /subscriptions/{id}/pause", h.pauseHandler)
+	mux.HandleFunc("POST /subscriptions/{id}/resume", h.resumeHandler)
+
+	if h.orgs != nil {
+		h.registerOrganizationHandlers(mux)
+	}
+	if h.budgets != nil {
+		h.registerBudgetHandlers(mux)
+	}
 }
 
 func (h *Handler) createHandler(w http.ResponseWriter, r *http.Request) {
@@ -42,14 +77,21 @@ func (h *Handler) createHandler(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&req); err != nil {
-		httpx.HttpError(w, http.StatusBadRequest, "Некорректный JSON")
+		h.writeError(w, r, http.StatusBadRequest, httpx.CodeInvalidJSON, "")
 		return
 	}
 
 	if err := validateCreateSubscription(req); err != nil {
-		httpx.HttpError(w, http.StatusBadRequest, err.Error())
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	mode, err := validateOverlapMode(r.URL.Query(), h.overlapMode)
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
 		return
 	}
+	ctx := services.WithOverlapMode(r.Context(), mode)
 
 	start, _ := time.Parse("01-2006", req.StartDate)
 	start = start.Local()
@@ -61,84 +103,63 @@ func (h *Handler) createHandler(w http.ResponseWriter, r *http.Request) {
 		endPtr = &tt
 	}
 
-	id, err := h.svc.Create(r.Context(), models.Subscription{
+	sub := models.Subscription{
 		ServiceName: req.ServiceName,
 		Price:       req.Price,
 		UserID:      req.UserID,
 		StartDate:   start,
 		EndDate:     endPtr,
-	})
+		TeamID:      req.TeamID,
+		Category:    strings.TrimSpace(req.Category),
+	}
+	switch {
+	case strings.TrimSpace(req.TrialEnd) != "":
+		t, _ := time.Parse("01-2006", req.TrialEnd)
+		tt := t.Local()
+		sub.TrialEnd = &tt
+	case req.TrialMonths != nil:
+		sub.TrialEnd = trialEnd(start, *req.TrialMonths)
+	}
+
+	id, err := h.svc.Create(ctx, sub)
 	if err != nil {
-		switch {
-		case errors.Is(err, services.ErrValidation):
-			httpx.HttpError(w, http.StatusBadRequest, err.Error())
-		default:
-			h.logger.Error("ошибка при создании подписки", zap.Error(err))
-			httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
-		}
+		h.writeServiceError(w, r, err, "ошибка при создании подписки")
 		return
 	}
 
-	if err := httpx.WriteJSON(w, http.StatusCreated, map[string]int{"id": id}); err != nil {
-		switch {
-		case errors.Is(err, httpx.ErrJSONMarshal):
-			h.logger.Error("не удалось сериализовать JSON", zap.Error(err))
-			httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
-		case errors.Is(err, httpx.ErrWriteBody):
-			h.logger.Warn("клиент закрыл соединение, ответ не был отправлен", zap.Error(err))
-		}
-		return
+	sub.ID = id
+	resp := createSubscriptionRes{ID: id}
+	if mode == services.OverlapWarn {
+		resp.Overlaps = h.warnOverlaps(ctx, w, sub)
 	}
+
+	h.writeJSON(w, r, http.StatusCreated, resp)
 }
 
 func (h *Handler) getHandler(w http.ResponseWriter, r *http.Request) {
-	idStr := r.PathValue("id")
-	id, err := strconv.Atoi(idStr)
-	if err != nil || id <= 0 {
-		httpx.HttpError(w, http.StatusBadRequest, "Некорректный ID")
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
 		return
 	}
 
 	dataItem, err := h.svc.GetByID(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, services.ErrNotFound) {
-			httpx.HttpError(w, http.StatusNotFound, "Подписка не найдена")
+			h.writeError(w, r, http.StatusNotFound, httpx.CodeNotFound, i18n.MsgSubscriptionNotFound)
 			return
 		}
-		h.logger.Error("Ошибка GetByID()",
-			zap.Int("id", id),
-			zap.Error(err),
-		)
-		httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
+		h.writeServiceError(w, r, err, "Ошибка GetByID()", zap.Int("id", id))
 		return
 	}
 
-	resp := subscriptionRes{
-		ID:          dataItem.ID,
-		ServiceName: dataItem.ServiceName,
-		Price:       dataItem.Price,
-		UserID:      dataItem.UserID,
-		StartDate:   dataItem.StartDate.Local().Format("01-2006"),
-	}
-	if dataItem.EndDate != nil {
-		resp.EndDate = dataItem.EndDate.Local().Format("01-2006")
-	}
-
-	if err := httpx.WriteJSON(w, http.StatusOK, resp); err != nil {
-		if errors.Is(err, httpx.ErrJSONMarshal) {
-			h.logger.Error("не удалось сериализовать JSON", zap.Error(err))
-			httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
-		} else if errors.Is(err, httpx.ErrWriteBody) {
-			h.logger.Warn("клиент закрыл соединение, ответ не был отправлен", zap.Error(err))
-		}
-	}
+	h.writeConditionalJSON(w, r, toSubscriptionRes(dataItem), dataItem.UpdatedAt)
 }
 
 func (h *Handler) updateHandler(w http.ResponseWriter, r *http.Request) {
-	idStr := r.PathValue("id")
-	id, err := strconv.Atoi(idStr)
-	if err != nil || id <= 0 {
-		httpx.HttpError(w, http.StatusBadRequest, "Некорректный ID")
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
 		return
 	}
 
@@ -147,26 +168,29 @@ func (h *Handler) updateHandler(w http.ResponseWriter, r *http.Request) {
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 	if err := decoder.Decode(&req); err != nil {
-		httpx.HttpError(w, http.StatusBadRequest, "Некорректный JSON")
+		h.writeError(w, r, http.StatusBadRequest, httpx.CodeInvalidJSON, "")
 		return
 	}
 
 	if err := validateUpdateSubscription(req); err != nil {
-		httpx.HttpError(w, http.StatusBadRequest, err.Error())
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	mode, err := validateOverlapMode(r.URL.Query(), h.overlapMode)
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
 		return
 	}
+	ctx := services.WithOverlapMode(r.Context(), mode)
 
 	dataItem, err := h.svc.GetByID(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, services.ErrNotFound) {
-			httpx.HttpError(w, http.StatusNotFound, "Подписка не найдена")
+			h.writeError(w, r, http.StatusNotFound, httpx.CodeNotFound, i18n.MsgSubscriptionNotFound)
 			return
 		}
-		h.logger.Error("Ошибка GetByID() при обновлении подписки",
-			zap.Int("id", id),
-			zap.Error(err),
-		)
-		httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
+		h.writeServiceError(w, r, err, "Ошибка GetByID() при обновлении подписки", zap.Int("id", id))
 		return
 	}
 
@@ -184,6 +208,13 @@ func (h *Handler) updateHandler(w http.ResponseWriter, r *http.Request) {
 		dataItem.StartDate = t.Local()
 	}
 
+	if req.TeamID.Set {
+		dataItem.TeamID = req.TeamID.Value
+	}
+	if req.Category != nil {
+		dataItem.Category = strings.TrimSpace(*req.Category)
+	}
+
 	if req.EndDate != nil {
 		if strings.TrimSpace(*req.EndDate) == "" {
 			dataItem.EndDate = nil
@@ -194,37 +225,47 @@ func (h *Handler) updateHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	if err := h.svc.Update(r.Context(), dataItem); err != nil {
-		switch {
-		case errors.Is(err, services.ErrValidation):
-			httpx.HttpError(w, http.StatusBadRequest, err.Error())
-		case errors.Is(err, services.ErrNotFound):
-			httpx.HttpError(w, http.StatusNotFound, "Подписка не найдена")
-		default:
-			h.logger.Error("Ошибка Update()", zap.Int("id", id), zap.Error(err))
-			httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
+	switch {
+	case req.TrialMonths != nil:
+		dataItem.TrialEnd = trialEnd(dataItem.StartDate, *req.TrialMonths)
+	case req.TrialEnd == nil:
+	case strings.TrimSpace(*req.TrialEnd) == "":
+		dataItem.TrialEnd = nil
+	default:
+		t, _ := time.Parse("01-2006", *req.TrialEnd)
+		tt := t.Local()
+		dataItem.TrialEnd = &tt
+	}
+
+	if err := h.svc.Update(ctx, dataItem); err != nil {
+		if errors.Is(err, services.ErrNotFound) {
+			h.writeError(w, r, http.StatusNotFound, httpx.CodeNotFound, i18n.MsgSubscriptionNotFound)
+			return
 		}
+		h.writeServiceError(w, r, err, "Ошибка Update()", zap.Int("id", id))
 		return
 	}
 
+	if mode == services.OverlapWarn {
+		h.warnOverlaps(ctx, w, dataItem)
+	}
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
 func (h *Handler) deleteHandler(w http.ResponseWriter, r *http.Request) {
-	idStr := r.PathValue("id")
-	id, err := strconv.Atoi(idStr)
-	if err != nil || id <= 0 {
-		httpx.HttpError(w, http.StatusBadRequest, "Некорректный ID")
+	id, err := validateID(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
 		return
 	}
 
 	if err := h.svc.Delete(r.Context(), id); err != nil {
 		if errors.Is(err, services.ErrNotFound) {
-			httpx.HttpError(w, http.StatusNotFound, "Подписка не найдена")
+			h.writeError(w, r, http.StatusNotFound, httpx.CodeNotFound, i18n.MsgSubscriptionNotFound)
 			return
 		}
-		h.logger.Error("Ошибка Delete()", zap.Int("id", id), zap.Error(err))
-		httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
+		h.writeServiceError(w, r, err, "Ошибка Delete()", zap.Int("id", id))
 		return
 	}
 
@@ -234,82 +275,153 @@ func (h *Handler) deleteHandler(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) listHandler(w http.ResponseWriter, r *http.Request) {
 	var filter services.ListFilter
 	if err := validateListSubscription(r.URL.Query(), &filter); err != nil {
-		httpx.HttpError(w, http.StatusBadRequest, err.Error())
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
 		return
 	}
 
 	data, err := h.svc.List(r.Context(), filter)
 	if err != nil {
-		h.logger.Error("Ошибка List()", zap.Error(err))
-		httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
+		h.writeServiceError(w, r, err, "Ошибка List()")
 		return
 	}
 
 	resp := make([]subscriptionRes, 0, len(data))
 
 	for _, dataItem := range data {
-		respItem := subscriptionRes{
-			ID:          dataItem.ID,
-			ServiceName: dataItem.ServiceName,
-			Price:       dataItem.Price,
-			UserID:      dataItem.UserID,
-			StartDate:   dataItem.StartDate.Local().Format("01-2006"),
-		}
-		if dataItem.EndDate != nil {
-			respItem.EndDate = dataItem.EndDate.Local().Format("01-2006")
-		}
-		resp = append(resp, respItem)
+		resp = append(resp, toSubscriptionRes(dataItem))
 	}
 
-	if err := httpx.WriteJSON(w, http.StatusOK, resp); err != nil {
-		switch {
-		case errors.Is(err, httpx.ErrJSONMarshal):
-			h.logger.Error("не удалось сериализовать JSON", zap.Error(err))
-			httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
-		case errors.Is(err, httpx.ErrWriteBody):
-			h.logger.Warn("клиент закрыл соединение, ответ не был отправлен", zap.Error(err))
-		}
-	}
+	// Last-Modified для списка не отдаётся: удаление записи не сдвигает max(updated_at).
+	h.writeConditionalJSON(w, r, resp, time.Time{})
 }
 
 func (h *Handler) totalCostHandler(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 
-	if err := validateTotalCost(query); err != nil {
-		httpx.HttpError(w, http.StatusBadRequest, err.Error())
+	var filter services.ListFilter
+	if err := validateTotalCost(query, &filter); err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	groupBy, err := validateGroupBy(query)
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
 		return
 	}
 
 	periodStart, _ := time.Parse("01-2006", strings.TrimSpace(query.Get("period_start")))
 	periodEnd, _ := time.Parse("01-2006", strings.TrimSpace(query.Get("period_end")))
 
-	filter := services.ListFilter{}
-	if userID := strings.TrimSpace(query.Get("user_id")); userID != "" {
-		filter.UserID, filter.HasUserID = userID, true
-	}
-	if serviceName := strings.TrimSpace(query.Get("service_name")); serviceName != "" {
-		filter.ServiceName, filter.HasServiceName = serviceName, true
+	if groupBy == "team" {
+		h.totalCostByTeam(w, r, periodStart, periodEnd, filter)
+		return
 	}
 
 	sum, err := h.svc.TotalCost(r.Context(), periodStart, periodEnd, filter)
 	if err != nil {
-		switch {
-		case errors.Is(err, services.ErrValidation):
-			httpx.HttpError(w, http.StatusBadRequest, err.Error())
-		default:
-			h.logger.Error("Ошибка TotalCost()", zap.Error(err))
-			httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
-		}
+		h.writeServiceError(w, r, err, "Ошибка TotalCost()")
+		return
+	}
+
+	h.writeJSON(w, r, http.StatusOK, map[string]int{"total_cost": sum})
+}
+
+func (h *Handler) totalCostByTeam(w http.ResponseWriter, r *http.Request, periodStart, periodEnd time.Time, filter services.ListFilter) {
+	teams, err := h.svc.TotalCostByTeam(r.Context(), periodStart, periodEnd, filter)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Ошибка TotalCostByTeam()")
+		return
+	}
+
+	resp := totalCostByTeamRes{Teams: make([]teamCostRes, 0, len(teams))}
+	for _, t := range teams {
+		resp.TotalCost += t.TotalCost
+		resp.Teams = append(resp.Teams, teamCostRes{TeamID: t.TeamID, TotalCost: t.TotalCost})
+	}
+
+	h.writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (h *Handler) forecastHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var filter services.ListFilter
+	from, months, err := validateForecast(query, &filter)
+	if err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+	data, err := h.svc.Forecast(r.Context(), from, months, filter)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Ошибка Forecast()")
+		return
+	}
+
+	resp := forecastRes{Months: make([]monthCostRes, 0, len(data))}
+	for _, m := range data {
+		resp.TotalCost += m.TotalCost
+		resp.Months = append(resp.Months, monthCostRes{Month: m.Month.Format("01-2006"), TotalCost: m.TotalCost})
+	}
+
+	h.writeJSON(w, r, http.StatusOK, resp)
+}
+
+func (h *Handler) duplicatesHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var filter services.ListFilter
+	if err := validateDuplicates(query, &filter); err != nil {
+		h.writeServiceError(w, r, err, "ошибка валидации запроса")
+		return
+	}
+
+	groups, err := h.svc.Duplicates(r.Context(), filter)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Ошибка Duplicates()")
 		return
 	}
 
-	if err := httpx.WriteJSON(w, http.StatusOK, map[string]int{"total_cost": sum}); err != nil {
-		switch {
-		case errors.Is(err, httpx.ErrJSONMarshal):
-			h.logger.Error("не удалось сериализовать JSON", zap.Error(err))
-			httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера")
-		case errors.Is(err, httpx.ErrWriteBody):
-			h.logger.Warn("клиент закрыл соединение, ответ не был отправлен", zap.Error(err))
+	resp := make([]duplicateGroupRes, 0, len(groups))
+	for _, group := range groups {
+		respItem := duplicateGroupRes{
+			UserID:        group.UserID,
+			ServiceName:   group.ServiceName,
+			Subscriptions: make([]subscriptionRes, 0, len(group.Subscriptions)),
 		}
+		for _, dataItem := range group.Subscriptions {
+			respItem.Subscriptions = append(respItem.Subscriptions, toSubscriptionRes(dataItem))
+		}
+		resp = append(resp, respItem)
+	}
+
+	h.writeJSON(w, r, http.StatusOK, resp)
+}
+
+// warnOverlaps ищет подписки, пересекающиеся с записанной, и сообщает их id в заголовке ответа.
+// Ошибка поиска не должна ломать уже выполненную запись, поэтому только логируется.
+func (h *Handler) warnOverlaps(ctx context.Context, w http.ResponseWriter, sub models.Subscription) []int {
+	found, err := h.svc.FindOverlaps(ctx, sub)
+	if err != nil {
+		h.log(ctx).Warn("не удалось проверить пересечения подписок", zap.Int("id", sub.ID), zap.Error(err))
+		return nil
+	}
+	if len(found) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(found))
+	strIDs := make([]string, 0, len(found))
+	for _, item := range found {
+		ids = append(ids, item.ID)
+		strIDs = append(strIDs, strconv.Itoa(item.ID))
 	}
+	w.Header().Set(overlapsHeader, strings.Join(strIDs, ","))
+	return ids
+}
+
+// trialEnd - последний бесплатный месяц пробного периода из months месяцев, начиная со start.
+func trialEnd(start time.Time, months int) *time.Time {
+	t := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.Local).AddDate(0, months-1, 0)
+	return &t
 }
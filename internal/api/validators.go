@@ -1,107 +1,518 @@
 package api
 
 import (
-	"fmt"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
 	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/models"
 )
 
+func fieldError(field, code, key string, args ...any) error {
+	return services.NewValidationError(services.FieldError{Field: field, Code: code, Key: key, Args: args})
+}
+
+// fieldErrors накапливает ошибки по полям, чтобы вернуть клиенту все сразу.
+type fieldErrors []services.FieldError
+
+func (e *fieldErrors) add(field, code, key string, args ...any) {
+	*e = append(*e, services.FieldError{Field: field, Code: code, Key: key, Args: args})
+}
+
+func (e fieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return services.NewValidationError(e...)
+}
+
 func validateCreateSubscription(req createSubscriptionReq) error {
+	var errs fieldErrors
+
 	if strings.TrimSpace(req.ServiceName) == "" {
-		return fmt.Errorf("service_name обязателен")
+		errs.add("service_name", services.CodeRequired, i18n.MsgFieldRequired, "service_name")
+	}
+	if req.Price < 0 {
+		errs.add("price", services.CodeNegative, i18n.MsgFieldNegative, "price")
 	}
 	if strings.TrimSpace(req.UserID) == "" {
-		return fmt.Errorf("user_id обязателен")
+		errs.add("user_id", services.CodeRequired, i18n.MsgFieldRequired, "user_id")
+	} else if !validUUID(req.UserID) {
+		errs.add("user_id", services.CodeInvalidFormat, i18n.MsgFieldUUID, "user_id")
+	}
+
+	if req.TeamID != nil && *req.TeamID <= 0 {
+		errs.add("team_id", services.CodeOutOfRange, i18n.MsgFieldID, "team_id")
 	}
 
-	if _, err := time.Parse("01-2006", req.StartDate); err != nil {
-		return fmt.Errorf("start_date должен быть в формате MM-YYYY")
+	start, startErr := time.Parse("01-2006", req.StartDate)
+	if startErr != nil {
+		errs.add("start_date", services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, "start_date")
 	}
 
 	if strings.TrimSpace(req.EndDate) != "" {
-		if _, err := time.Parse("01-2006", req.EndDate); err != nil {
-			return fmt.Errorf("end_date должен быть в формате MM-YYYY")
+		end, err := time.Parse("01-2006", req.EndDate)
+		switch {
+		case err != nil:
+			errs.add("end_date", services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, "end_date")
+		case startErr == nil && end.Before(start):
+			errs.add("end_date", services.CodeBeforeStart, i18n.MsgFieldBeforeStart, "end_date", "start_date")
 		}
 	}
-	return nil
+
+	if strings.TrimSpace(req.TrialEnd) != "" {
+		trialEnd, err := time.Parse("01-2006", req.TrialEnd)
+		switch {
+		case err != nil:
+			errs.add("trial_end", services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, "trial_end")
+		case startErr == nil && trialEnd.Before(start):
+			errs.add("trial_end", services.CodeBeforeStart, i18n.MsgFieldBeforeStart, "trial_end", "start_date")
+		}
+	}
+	validateTrialMonths(strings.TrimSpace(req.TrialEnd) != "", req.TrialMonths, &errs)
+	return errs.err()
+}
+
+// validateTrialMonths - пробный период задаётся либо месяцем окончания, либо числом бесплатных месяцев.
+func validateTrialMonths(hasTrialEnd bool, trialMonths *int, errs *fieldErrors) {
+	switch {
+	case trialMonths == nil:
+	case hasTrialEnd:
+		errs.add("trial_months", services.CodeInvalidValue, i18n.MsgTrialExclusive)
+	case *trialMonths <= 0:
+		errs.add("trial_months", services.CodeOutOfRange, i18n.MsgFieldID, "trial_months")
+	}
 }
 
 func validateUpdateSubscription(req updateSubscriptionReq) error {
-	if req.ServiceName == nil && req.Price == nil && req.UserID == nil && req.StartDate == nil && req.EndDate == nil {
-		return fmt.Errorf("необходимо указать хотя бы одно поле для обновления")
+	if req.ServiceName == nil && req.Price == nil && req.UserID == nil && req.StartDate == nil && req.EndDate == nil &&
+		!req.TeamID.Set && req.Category == nil && req.TrialEnd == nil && req.TrialMonths == nil {
+		return fieldError("", services.CodeNoFields, i18n.MsgNoFields)
 	}
 
+	var errs fieldErrors
+
 	if req.ServiceName != nil && strings.TrimSpace(*req.ServiceName) == "" {
-		return fmt.Errorf("service_name не может быть пустым")
+		errs.add("service_name", services.CodeEmpty, i18n.MsgFieldEmpty, "service_name")
+	}
+
+	if req.Price != nil && *req.Price < 0 {
+		errs.add("price", services.CodeNegative, i18n.MsgFieldNegative, "price")
+	}
+
+	if req.TeamID.Value != nil && *req.TeamID.Value <= 0 {
+		errs.add("team_id", services.CodeOutOfRange, i18n.MsgFieldID, "team_id")
 	}
 
-	if req.UserID != nil && strings.TrimSpace(*req.UserID) == "" {
-		return fmt.Errorf("user_id не может быть пустым")
+	if req.UserID != nil {
+		if strings.TrimSpace(*req.UserID) == "" {
+			errs.add("user_id", services.CodeEmpty, i18n.MsgFieldEmpty, "user_id")
+		} else if !validUUID(*req.UserID) {
+			errs.add("user_id", services.CodeInvalidFormat, i18n.MsgFieldUUID, "user_id")
+		}
 	}
 
+	var (
+		start, end     time.Time
+		startOK, endOK bool
+	)
 	if req.StartDate != nil {
-		if _, err := time.Parse("01-2006", *req.StartDate); err != nil {
-			return fmt.Errorf("start_date должен быть в формате MM-YYYY")
+		t, err := time.Parse("01-2006", *req.StartDate)
+		if err != nil {
+			errs.add("start_date", services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, "start_date")
 		}
+		start, startOK = t, err == nil
 	}
 
 	if req.EndDate != nil {
-		if _, err := time.Parse("01-2006", *req.EndDate); err != nil {
-			return fmt.Errorf("end_date должен быть в формате MM-YYYY")
+		t, err := time.Parse("01-2006", *req.EndDate)
+		if err != nil {
+			errs.add("end_date", services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, "end_date")
 		}
+		end, endOK = t, err == nil
 	}
 
-	return nil
+	// Если изменяется только одна из дат, порядок проверит сервис по итоговой записи.
+	if startOK && endOK && end.Before(start) {
+		errs.add("end_date", services.CodeBeforeStart, i18n.MsgFieldBeforeStart, "end_date", "start_date")
+	}
+
+	if req.TrialEnd != nil && strings.TrimSpace(*req.TrialEnd) != "" {
+		if _, err := time.Parse("01-2006", *req.TrialEnd); err != nil {
+			errs.add("trial_end", services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, "trial_end")
+		}
+	}
+	validateTrialMonths(req.TrialEnd != nil, req.TrialMonths, &errs)
+
+	return errs.err()
+}
+
+func validUUID(s string) bool {
+	return uuid.Validate(strings.TrimSpace(s)) == nil
+}
+
+func validateID(idStr string) (int, error) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		return 0, fieldError("id", services.CodeInvalidFormat, i18n.MsgInvalidID)
+	}
+	return id, nil
 }
 
 func validateListSubscription(query url.Values, filter *services.ListFilter) error {
 	filter.Limit = 50
 	filter.Offset = 0
 
-	if userID := strings.TrimSpace(query.Get("user_id")); userID != "" {
-		filter.UserID, filter.HasUserID = userID, true
-	}
-	if serviceName := strings.TrimSpace(query.Get("service_name")); serviceName != "" {
-		filter.ServiceName, filter.HasServiceName = serviceName, true
+	var errs fieldErrors
+	parseFilter(query, filter, &errs)
+	filter.Sort = parseSort(query, &errs)
+
+	if raw := strings.TrimSpace(query.Get("include_shared")); raw != "" {
+		shared, err := strconv.ParseBool(raw)
+		if err != nil {
+			errs.add("include_shared", services.CodeInvalidFormat, i18n.MsgFieldBool, "include_shared")
+		}
+		filter.IncludeShared = shared
 	}
 
 	if limitStr := strings.TrimSpace(query.Get("limit")); limitStr != "" {
 		limit, err := strconv.Atoi(limitStr)
 		if err != nil || limit <= 0 || limit > 100 {
-			return fmt.Errorf("limit должен быть числом от 1 до 100")
+			errs.add("limit", services.CodeOutOfRange, i18n.MsgLimitRange)
+		} else {
+			filter.Limit = limit
 		}
-		filter.Limit = limit
 	}
 
 	if offsetStr := strings.TrimSpace(query.Get("offset")); offsetStr != "" {
 		offset, err := strconv.Atoi(offsetStr)
 		if err != nil || offset < 0 {
-			return fmt.Errorf("offset должен быть числом >= 0")
+			errs.add("offset", services.CodeOutOfRange, i18n.MsgOffsetRange)
+		} else {
+			filter.Offset = offset
 		}
-		filter.Offset = offset
 	}
-	return nil
+	return errs.err()
+}
+
+func validateTotalCost(query url.Values, filter *services.ListFilter) error {
+	var errs fieldErrors
+
+	for _, field := range []string{"period_start", "period_end"} {
+		raw := strings.TrimSpace(query.Get(field))
+		if raw == "" {
+			errs.add(field, services.CodeRequired, i18n.MsgFieldRequired, field)
+			continue
+		}
+		if _, err := time.Parse("01-2006", raw); err != nil {
+			errs.add(field, services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, field)
+		}
+	}
+
+	parseFilter(query, filter, &errs)
+	return errs.err()
+}
+
+const (
+	maxQueryLen     = 100
+	maxFilterValues = 50
+)
+
+// parseFilter разбирает фильтры, общие для списка подписок и суммы за период.
+func parseFilter(query url.Values, filter *services.ListFilter, errs *fieldErrors) {
+	filter.UserIDs = parseValues(query, "user_id", errs)
+	filter.ServiceNames = parseValues(query, "service_name", errs)
+	filter.Categories = parseValues(query, "category", errs)
+	filter.TeamIDs = parseIDs(query, "team_id", errs)
+	filter.OrganizationIDs = parseIDs(query, "organization_id", errs)
+
+	if q := strings.TrimSpace(query.Get("q")); q != "" {
+		if utf8.RuneCountInString(q) > maxQueryLen {
+			errs.add("q", services.CodeOutOfRange, i18n.MsgQueryTooLong, maxQueryLen)
+		} else {
+			filter.Query, filter.HasQuery = q, true
+		}
+	}
+
+	filter.CreatedAfter, filter.HasCreatedAfter = parseTimestamp(query, "created_after", errs)
+	filter.UpdatedAfter, filter.HasUpdatedAfter = parseTimestamp(query, "updated_after", errs)
+
+	filter.PriceMin, filter.HasPriceMin = parseNonNegative(query, "price_min", errs)
+	filter.PriceMax, filter.HasPriceMax = parseNonNegative(query, "price_max", errs)
+	if filter.HasPriceMin && filter.HasPriceMax && filter.PriceMax < filter.PriceMin {
+		errs.add("price_max", services.CodeOutOfRange, i18n.MsgFieldLessThan, "price_max", "price_min")
+	}
+
+	filter.StartFrom, filter.HasStartFrom = parseMonth(query, "start_from", errs)
+	filter.StartTo, filter.HasStartTo = parseMonth(query, "start_to", errs)
+	if filter.HasStartFrom && filter.HasStartTo && filter.StartTo.Before(filter.StartFrom) {
+		errs.add("start_to", services.CodeBeforeStart, i18n.MsgFieldBeforeStart, "start_to", "start_from")
+	}
+
+	filter.ActiveAt, filter.HasActiveAt = parseMonth(query, "active_at", errs)
+	filter.TrialEndsWithin, filter.HasTrialEndsWithin = parseNonNegative(query, "trial_ends_within", errs)
+
+	if raw := strings.TrimSpace(query.Get("status")); raw != "" {
+		status, ok := services.ParseStatus(raw)
+		if !ok {
+			errs.add("status", services.CodeInvalidValue, i18n.MsgStatus)
+		}
+		filter.Status = status
+	}
+}
+
+// parseSort разбирает sort=-price,service_name: поля через запятую, "-" - по убыванию.
+func parseSort(query url.Values, errs *fieldErrors) []services.SortKey {
+	raw := strings.TrimSpace(query.Get("sort"))
+	if raw == "" {
+		return nil
+	}
+
+	var (
+		keys []services.SortKey
+		seen = make(map[string]bool)
+	)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		field, desc := strings.CutPrefix(part, "-")
+		switch {
+		case !slices.Contains(services.SortFields, field):
+			errs.add("sort", services.CodeInvalidValue, i18n.MsgSortField, part, strings.Join(services.SortFields, ", "))
+		case seen[field]:
+			errs.add("sort", services.CodeInvalidValue, i18n.MsgSortDuplicate, field)
+		default:
+			seen[field] = true
+			keys = append(keys, services.SortKey{Field: field, Desc: desc})
+		}
+	}
+	return keys
+}
+
+// parseValues разбирает многозначный фильтр: повторённый параметр (user_id=a&user_id=b)
+// и/или значения через запятую. Пустые значения и повторы отбрасываются.
+func parseValues(query url.Values, field string, errs *fieldErrors) []string {
+	var values []string
+	for _, raw := range query[field] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" && !slices.Contains(values, v) {
+				values = append(values, v)
+			}
+		}
+	}
+	if len(values) > maxFilterValues {
+		errs.add(field, services.CodeOutOfRange, i18n.MsgTooManyValues, field, maxFilterValues)
+		return nil
+	}
+	return values
+}
+
+// parseIDs - многозначный фильтр по положительным целым id.
+func parseIDs(query url.Values, field string, errs *fieldErrors) []int {
+	var ids []int
+	for _, raw := range parseValues(query, field, errs) {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			errs.add(field, services.CodeInvalidFormat, i18n.MsgFieldID, field)
+			return nil
+		}
+		ids = append(ids, id)
+	}
+	return ids
 }
 
-func validateTotalCost(query url.Values) error {
-	startDate := strings.TrimSpace(query.Get("period_start"))
-	endDate := strings.TrimSpace(query.Get("period_end"))
+func parseTimestamp(query url.Values, field string, errs *fieldErrors) (time.Time, bool) {
+	raw := strings.TrimSpace(query.Get(field))
+	if raw == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		errs.add(field, services.CodeInvalidFormat, i18n.MsgFieldTimestamp, field)
+		return time.Time{}, false
+	}
+	return t, true
+}
 
-	if startDate == "" || endDate == "" {
-		return fmt.Errorf("period_start и period_end не могут быть пустыми")
+func parseMonth(query url.Values, field string, errs *fieldErrors) (time.Time, bool) {
+	raw := strings.TrimSpace(query.Get(field))
+	if raw == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("01-2006", raw)
+	if err != nil {
+		errs.add(field, services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, field)
+		return time.Time{}, false
 	}
+	return t, true
+}
 
-	if _, err := time.Parse("01-2006", startDate); err != nil {
-		return fmt.Errorf("period_start должен быть в формате MM-YYYY")
+func parseNonNegative(query url.Values, field string, errs *fieldErrors) (int, bool) {
+	raw := strings.TrimSpace(query.Get(field))
+	if raw == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(raw)
+	switch {
+	case err != nil:
+		errs.add(field, services.CodeInvalidFormat, i18n.MsgFieldInteger, field)
+		return 0, false
+	case n < 0:
+		errs.add(field, services.CodeNegative, i18n.MsgFieldNegative, field)
+		return 0, false
 	}
+	return n, true
+}
+
+func validateDuplicates(query url.Values, filter *services.ListFilter) error {
+	var errs fieldErrors
+	filter.UserIDs = parseValues(query, "user_id", &errs)
+	filter.ServiceNames = parseValues(query, "service_name", &errs)
+	return errs.err()
+}
 
-	if _, err := time.Parse("01-2006", endDate); err != nil {
-		return fmt.Errorf("period_end должен быть в формате MM-YYYY")
+// validateGroupBy - разбивка суммы за период: пусто (без разбивки) или team.
+func validateGroupBy(query url.Values) (string, error) {
+	switch groupBy := strings.TrimSpace(query.Get("group_by")); groupBy {
+	case "", "team":
+		return groupBy, nil
+	default:
+		return "", fieldError("group_by", services.CodeInvalidValue, i18n.MsgGroupBy)
 	}
+}
 
+func validateName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fieldError("name", services.CodeRequired, i18n.MsgFieldRequired, "name")
+	}
 	return nil
 }
+
+func validateMember(userID string, req memberReq) error {
+	var errs fieldErrors
+	if !validUUID(userID) {
+		errs.add("user_id", services.CodeInvalidFormat, i18n.MsgFieldUUID, "user_id")
+	}
+	switch req.Role {
+	case "", models.RoleMember, models.RoleAdmin:
+	default:
+		errs.add("role", services.CodeInvalidValue, i18n.MsgRole)
+	}
+	return errs.err()
+}
+
+func validateParticipant(userID string, req participantReq) error {
+	var errs fieldErrors
+	if !validUUID(userID) {
+		errs.add("user_id", services.CodeInvalidFormat, i18n.MsgFieldUUID, "user_id")
+	}
+	switch {
+	case (req.ShareWeight == nil) == (req.FixedAmount == nil):
+		errs.add("share_weight", services.CodeInvalidValue, i18n.MsgParticipantShare)
+	case req.ShareWeight != nil && *req.ShareWeight <= 0:
+		errs.add("share_weight", services.CodeOutOfRange, i18n.MsgFieldID, "share_weight")
+	case req.FixedAmount != nil && *req.FixedAmount < 0:
+		errs.add("fixed_amount", services.CodeNegative, i18n.MsgFieldNegative, "fixed_amount")
+	}
+	return errs.err()
+}
+
+const defaultForecastMonths = 12
+
+// validateForecast разбирает ?months (по умолчанию 12), ?from (по умолчанию текущий месяц - нулевое время) и фильтры.
+func validateForecast(query url.Values, filter *services.ListFilter) (time.Time, int, error) {
+	var errs fieldErrors
+
+	months := defaultForecastMonths
+	if raw := strings.TrimSpace(query.Get("months")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > services.MaxForecastMonths {
+			errs.add("months", services.CodeOutOfRange, i18n.MsgForecastMonths, services.MaxForecastMonths)
+		} else {
+			months = n
+		}
+	}
+	from, _ := parseMonth(query, "from", &errs)
+
+	parseFilter(query, filter, &errs)
+	return from, months, errs.err()
+}
+
+func validatePriceChange(month string, req priceChangeReq) (time.Time, error) {
+	var errs fieldErrors
+	effectiveFrom, err := time.Parse("01-2006", strings.TrimSpace(month))
+	if err != nil {
+		errs.add("effective_from", services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, "effective_from")
+	}
+	switch {
+	case req.Price == nil:
+		errs.add("price", services.CodeRequired, i18n.MsgFieldRequired, "price")
+	case *req.Price < 0:
+		errs.add("price", services.CodeNegative, i18n.MsgFieldNegative, "price")
+	}
+	return effectiveFrom, errs.err()
+}
+
+// validatePause разбирает месяцы паузы; пустой from - нулевое время (текущий месяц).
+func validatePause(req pauseReq) (time.Time, *time.Time, error) {
+	var errs fieldErrors
+	from := parseOptionalMonth(req.From, "from", &errs)
+	var until *time.Time
+	if u := parseOptionalMonth(req.Until, "until", &errs); !u.IsZero() {
+		until = &u
+		if !from.IsZero() && u.Before(from) {
+			errs.add("until", services.CodeBeforeStart, i18n.MsgFieldBeforeStart, "until", "from")
+		}
+	}
+	return from, until, errs.err()
+}
+
+func parseOptionalMonth(raw, field string, errs *fieldErrors) time.Time {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse("01-2006", raw)
+	if err != nil {
+		errs.add(field, services.CodeInvalidFormat, i18n.MsgFieldMonthFormat, field)
+		return time.Time{}
+	}
+	return t.Local()
+}
+
+func validateBudget(req budgetReq) error {
+	var errs fieldErrors
+	switch {
+	case (req.UserID == nil) == (req.TeamID == nil):
+		errs.add("user_id", services.CodeInvalidValue, i18n.MsgBudgetOwner)
+	case req.UserID != nil && !validUUID(*req.UserID):
+		errs.add("user_id", services.CodeInvalidFormat, i18n.MsgFieldUUID, "user_id")
+	case req.TeamID != nil && *req.TeamID <= 0:
+		errs.add("team_id", services.CodeOutOfRange, i18n.MsgFieldID, "team_id")
+	}
+	if req.Period != models.BudgetMonthly && req.Period != models.BudgetYearly {
+		errs.add("period", services.CodeInvalidValue, i18n.MsgBudgetPeriod)
+	}
+	if req.Amount <= 0 {
+		errs.add("amount", services.CodeOutOfRange, i18n.MsgFieldID, "amount")
+	}
+	return errs.err()
+}
+
+func validateOverlapMode(query url.Values, def services.OverlapMode) (services.OverlapMode, error) {
+	raw := strings.TrimSpace(query.Get("on_overlap"))
+	if raw == "" {
+		return def, nil
+	}
+	mode, ok := services.ParseOverlapMode(raw)
+	if !ok {
+		return "", fieldError("on_overlap", services.CodeInvalidValue, i18n.MsgOverlapMode)
+	}
+	return mode, nil
+}
@@ -5,8 +5,22 @@ import "time"
 type Config struct {
 	HTTPPort    string         `envconfig:"HTTP_PORT" default:"8080"`
 	HTTPTimeout time.Duration  `envconfig:"HTTP_TIMEOUT" default:"30s"`
+	AdminPort   string         `envconfig:"ADMIN_PORT" default:"9090"`
+	DrainDelay  time.Duration  `envconfig:"SHUTDOWN_DRAIN_DELAY" default:"5s"`
 	LogLevel    string         `envconfig:"LOG_LEVEL" default:"info"`
 	Postgres    PostgresConfig `envconfig:"POSTGRES"`
+	Tracing     TracingConfig  `envconfig:"TRACING"`
+	Cache       CacheConfig    `envconfig:"CACHE"`
+	Budget      BudgetConfig   `envconfig:"BUDGET"`
+
+	IdempotencyTTL     time.Duration `envconfig:"IDEMPOTENCY_TTL" default:"24h"`
+	IdempotencyMaxBody int64         `envconfig:"IDEMPOTENCY_MAX_BODY" default:"1048576"`
+	// IdempotencyPurgeInterval - период удаления истёкших ключей; 0 - удаление выключено.
+	IdempotencyPurgeInterval time.Duration `envconfig:"IDEMPOTENCY_PURGE_INTERVAL" default:"1h"`
+	OverlapMode              string        `envconfig:"OVERLAP_MODE" default:"warn"`
+	AuthEnabled              bool          `envconfig:"AUTH_ENABLED" default:"false"`
+	// TrustedProxies - CIDR или IP прокси, от которых принимается X-Forwarded-For; пустой - заголовок игнорируется.
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
 }
 
 type PostgresConfig struct {
@@ -21,4 +35,34 @@ type PostgresConfig struct {
 	MaxConnTTL        time.Duration `envconfig:"MAX_CONN_TTL" default:"1h"`
 	HealthCheckPeriod time.Duration `envconfig:"HEALTH_CHECK_PERIOD" default:"30s"`
 	PingTimeout       time.Duration `envconfig:"PING_TIMEOUT" default:"5s"`
+	RetryMaxAttempts  int           `envconfig:"RETRY_MAX_ATTEMPTS" default:"3"`
+	RetryBaseDelay    time.Duration `envconfig:"RETRY_BASE_DELAY" default:"50ms"`
+	RetryMaxDelay     time.Duration `envconfig:"RETRY_MAX_DELAY" default:"1s"`
+
+	// Реплика для чтения; пустой ReplicaHost - реплика не используется.
+	ReplicaHost        string        `envconfig:"REPLICA_HOST"`
+	ReplicaPort        string        `envconfig:"REPLICA_PORT" default:"5432"`
+	ReplicaCheckPeriod time.Duration `envconfig:"REPLICA_CHECK_PERIOD" default:"5s"`
+}
+
+type TracingConfig struct {
+	Exporter     string  `envconfig:"EXPORTER" default:"none"`
+	ServiceName  string  `envconfig:"SERVICE_NAME" default:"subscription-aggregator"`
+	SampleRatio  float64 `envconfig:"SAMPLE_RATIO" default:"1"`
+	OTLPEndpoint string  `envconfig:"OTLP_ENDPOINT" default:"localhost:4318"`
+	OTLPInsecure bool    `envconfig:"OTLP_INSECURE" default:"true"`
+}
+
+type BudgetConfig struct {
+	// CheckInterval - период фоновой проверки порогов бюджетов; 0 - проверка выключена.
+	CheckInterval time.Duration `envconfig:"CHECK_INTERVAL" default:"1h"`
+	// WebhookURL - адрес для уведомлений о бюджетах; пустой - уведомления пишутся в лог.
+	WebhookURL     string        `envconfig:"WEBHOOK_URL"`
+	WebhookTimeout time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"5s"`
+}
+
+type CacheConfig struct {
+	Enabled bool          `envconfig:"ENABLED" default:"true"`
+	Size    int           `envconfig:"SIZE" default:"10000"`
+	TTL     time.Duration `envconfig:"TTL" default:"30s"`
 }
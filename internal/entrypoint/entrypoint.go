@@ -9,48 +9,128 @@ import (
 	"syscall"
 
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/sunr3d/subscription-aggregator/internal/api"
 	"github.com/sunr3d/subscription-aggregator/internal/config"
+	"github.com/sunr3d/subscription-aggregator/internal/health"
+	"github.com/sunr3d/subscription-aggregator/internal/infra/lru"
+	"github.com/sunr3d/subscription-aggregator/internal/infra/notifier"
 	"github.com/sunr3d/subscription-aggregator/internal/infra/postgres"
 	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/metrics"
 	"github.com/sunr3d/subscription-aggregator/internal/middleware"
 	"github.com/sunr3d/subscription-aggregator/internal/server"
+	"github.com/sunr3d/subscription-aggregator/internal/services/budget_service"
+	"github.com/sunr3d/subscription-aggregator/internal/services/organization_service"
 	"github.com/sunr3d/subscription-aggregator/internal/services/subscription_service"
+	"github.com/sunr3d/subscription-aggregator/internal/tracing"
 )
 
 func Run(cfg *config.Config, logger *zap.Logger) error {
 	appCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Трейсинг
+	shutdownTracing, err := tracing.Init(appCtx, cfg.Tracing, logger)
+	if err != nil {
+		return fmt.Errorf("tracing.Init(): %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTPTimeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("Не удалось корректно завершить трейсинг", zap.Error(err))
+		}
+	}()
+
 	// Инфра слой
 	db, err := postgres.New(cfg.Postgres, logger)
 	if err != nil {
 		return fmt.Errorf("postgres.New(): %w", err)
 	}
-	defer func(db infra.Database) {
-		if c, ok := db.(interface{ Close() }); ok {
-			c.Close()
-		}
-	}(db)
+	defer db.Close()
 
 	// Сервисный слой
 	svc := subscription_service.New(db)
+	if cfg.Cache.Enabled {
+		svc = subscription_service.WithCache(svc, lru.New(cfg.Cache.Size), cfg.Cache.TTL)
+	}
+	// Авторизация снаружи кеша: права проверяются и для ответов из кеша.
+	svc = subscription_service.WithAuthorization(svc, db)
+	svc = subscription_service.WithTracing(svc)
+	orgSvc := organization_service.New(db)
+
+	var notify infra.Notifier = notifier.NewLog(logger)
+	if cfg.Budget.WebhookURL != "" {
+		notify = notifier.NewWebhook(cfg.Budget.WebhookURL, cfg.Budget.WebhookTimeout)
+	}
+	budgetSvc := budget_service.New(db, db, svc, notify)
 
 	// API
-	controller := api.New(svc, logger)
+	overlapMode, ok := services.ParseOverlapMode(cfg.OverlapMode)
+	if !ok {
+		return fmt.Errorf("некорректный OVERLAP_MODE %q: ожидается warn или reject", cfg.OverlapMode)
+	}
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("некорректный TRUSTED_PROXIES: %w", err)
+	}
+	controller := api.New(svc, logger,
+		api.WithOverlapMode(overlapMode),
+		api.WithOrganizations(orgSvc),
+		api.WithBudgets(budgetSvc),
+	)
 	mux := http.NewServeMux()
 	controller.RegisterHandlers(mux)
 
+	// Пробы liveness/readiness
+	probe := health.New(db, cfg.Postgres.PingTimeout, logger)
+	probe.RegisterHandlers(mux)
+
 	// Middleware
-	handler := middleware.Recovery(logger)(
-		middleware.ReqLogger(logger)(
-			middleware.JSONValidator(logger)(mux),
+	handler := middleware.RequestID(logger)(
+		middleware.Language()(
+			middleware.Recovery(logger)(
+				middleware.Metrics(mux)(
+					middleware.Tracing(mux)(
+						middleware.ReqLogger(logger, trustedProxies)(
+							middleware.Auth(cfg.AuthEnabled)(
+								middleware.JSONValidator(logger)(
+									middleware.Idempotency(db, cfg.IdempotencyTTL, cfg.IdempotencyMaxBody, logger)(
+										middleware.ReadConsistency()(mux),
+									),
+								),
+							),
+						),
+					),
+				),
+			),
 		),
 	)
 
-	// HTTP сервер
-	srv := server.New(cfg.HTTPPort, handler, cfg.HTTPTimeout, logger)
+	// Метрики
+	metrics.Registry.MustRegister(postgres.NewCollector(db))
+	adminMux := http.NewServeMux()
+	adminMux.Handle("GET /metrics", metrics.Handler())
+
+	// HTTP серверы: основной и admin
+	srv := server.New(cfg.HTTPPort, handler, cfg.HTTPTimeout, logger,
+		server.WithBeforeShutdown(probe.SetShuttingDown),
+		server.WithDrainDelay(cfg.DrainDelay),
+	)
+	adminSrv := server.New(cfg.AdminPort, adminMux, cfg.HTTPTimeout, logger)
+
+	g, gCtx := errgroup.WithContext(appCtx)
+	g.Go(func() error { return srv.Start(gCtx) })
+	g.Go(func() error { return adminSrv.Start(gCtx) })
+	if cfg.Budget.CheckInterval > 0 {
+		g.Go(func() error { return budget_service.RunEvaluator(gCtx, budgetSvc, cfg.Budget.CheckInterval, logger) })
+	}
+	if cfg.IdempotencyPurgeInterval > 0 {
+		g.Go(func() error { return middleware.RunIdempotencyPurge(gCtx, db, cfg.IdempotencyPurgeInterval, logger) })
+	}
 
-	return srv.Start(appCtx)
+	return g.Wait()
 }
@@ -0,0 +1,98 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/logger"
+)
+
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+type probeRes struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Probe отдаёт liveness (/healthz) и readiness (/readyz) пробы.
+type Probe struct {
+	db           infra.HealthChecker
+	timeout      time.Duration
+	logger       *zap.Logger
+	shuttingDown atomic.Bool
+}
+
+func New(db infra.HealthChecker, timeout time.Duration, logger *zap.Logger) *Probe {
+	return &Probe{
+		db:      db,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+func (p *Probe) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", p.livenessHandler)
+	mux.HandleFunc("GET /readyz", p.readinessHandler)
+}
+
+// SetShuttingDown переводит readiness в состояние отказа, чтобы балансировщик снял трафик до остановки сервера.
+func (p *Probe) SetShuttingDown() {
+	if !p.shuttingDown.Swap(true) {
+		p.logger.Info("Readiness проба переведена в состояние отказа: сервис завершает работу")
+	}
+}
+
+func (p *Probe) livenessHandler(w http.ResponseWriter, r *http.Request) {
+	p.write(w, r, http.StatusOK, probeRes{Status: statusOK})
+}
+
+func (p *Probe) readinessHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context(), p.logger)
+	checks := make(map[string]string, 3)
+	ready := true
+
+	if p.shuttingDown.Load() {
+		checks["shutdown"] = "сервис завершает работу"
+		ready = false
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), p.timeout)
+	defer cancel()
+
+	if err := p.db.Ping(ctx); err != nil {
+		log.Warn("Readiness: БД недоступна", zap.Error(err))
+		checks["database"] = err.Error()
+		ready = false
+	} else {
+		checks["database"] = statusOK
+		if err := p.db.CheckMigrations(ctx); err != nil {
+			log.Warn("Readiness: миграции не применены", zap.Error(err))
+			checks["migrations"] = err.Error()
+			ready = false
+		} else {
+			checks["migrations"] = statusOK
+		}
+	}
+
+	if !ready {
+		p.write(w, r, http.StatusServiceUnavailable, probeRes{Status: statusUnavailable, Checks: checks})
+		return
+	}
+	p.write(w, r, http.StatusOK, probeRes{Status: statusOK, Checks: checks})
+}
+
+func (p *Probe) write(w http.ResponseWriter, r *http.Request, code int, res probeRes) {
+	w.Header().Set("Cache-Control", "no-store")
+	if err := httpx.WriteJSON(w, code, res); err != nil {
+		logger.FromContext(r.Context(), p.logger).Warn("не удалось записать ответ пробы", zap.Error(err))
+	}
+}
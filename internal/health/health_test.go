@@ -0,0 +1,106 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/health"
+	"github.com/sunr3d/subscription-aggregator/mocks"
+)
+
+type probeRes struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func serve(t *testing.T, p *health.Probe, path string) (int, probeRes) {
+	t.Helper()
+	mux := http.NewServeMux()
+	p.RegisterHandlers(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var res probeRes
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	return w.Code, res
+}
+
+func TestLiveness(t *testing.T) {
+	p := health.New(mocks.NewHealthChecker(t), time.Second, zap.NewNop())
+
+	code, res := serve(t, p, "/healthz")
+
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ok", res.Status)
+}
+
+func TestReadiness_OK(t *testing.T) {
+	db := mocks.NewHealthChecker(t)
+	db.EXPECT().Ping(mock.Anything).Return(nil)
+	db.EXPECT().CheckMigrations(mock.Anything).Return(nil)
+
+	code, res := serve(t, health.New(db, time.Second, zap.NewNop()), "/readyz")
+
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]string{"database": "ok", "migrations": "ok"}, res.Checks)
+}
+
+func TestReadiness_DatabaseDown(t *testing.T) {
+	db := mocks.NewHealthChecker(t)
+	db.EXPECT().Ping(mock.Anything).Return(errors.New("connection refused"))
+
+	code, res := serve(t, health.New(db, time.Second, zap.NewNop()), "/readyz")
+
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "unavailable", res.Status)
+	require.Equal(t, "connection refused", res.Checks["database"])
+	require.NotContains(t, res.Checks, "migrations")
+}
+
+func TestReadiness_MigrationsMissing(t *testing.T) {
+	db := mocks.NewHealthChecker(t)
+	db.EXPECT().Ping(mock.Anything).Return(nil)
+	db.EXPECT().CheckMigrations(mock.Anything).Return(errors.New("нет таблицы budgets"))
+
+	code, res := serve(t, health.New(db, time.Second, zap.NewNop()), "/readyz")
+
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "нет таблицы budgets", res.Checks["migrations"])
+}
+
+func TestReadiness_ShuttingDown(t *testing.T) {
+	db := mocks.NewHealthChecker(t)
+	db.EXPECT().Ping(mock.Anything).Return(nil)
+	db.EXPECT().CheckMigrations(mock.Anything).Return(nil)
+
+	p := health.New(db, time.Second, zap.NewNop())
+	p.SetShuttingDown()
+	code, res := serve(t, p, "/readyz")
+
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Contains(t, res.Checks, "shutdown")
+	require.Equal(t, "ok", res.Checks["database"])
+}
+
+func TestReadiness_PingTimeout(t *testing.T) {
+	db := mocks.NewHealthChecker(t)
+	db.EXPECT().Ping(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	code, _ := serve(t, health.New(db, 10*time.Millisecond, zap.NewNop()), "/readyz")
+
+	require.Equal(t, http.StatusServiceUnavailable, code)
+}
@@ -0,0 +1,67 @@
+package httpx
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CacheControlRevalidate - ответ можно хранить только в кеше клиента и перед каждым использованием
+// нужно проверить его через If-None-Match: данные подписок меняются в любой момент.
+const CacheControlRevalidate = "private, no-cache"
+
+// WriteConditionalJSON пишет JSON со strong ETag, вычисленным по телу ответа, и Cache-Control.
+// Если клиент прислал совпадающий If-None-Match (или, без него, If-Modified-Since не раньше
+// lastModified), отвечает 304 без тела. Нулевой lastModified - Last-Modified не отдаётся.
+func WriteConditionalJSON(w http.ResponseWriter, r *http.Request, v any, lastModified time.Time) error {
+	buff, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrJSONMarshal, err)
+	}
+
+	sum := sha256.Sum256(buff)
+	etag := `"` + base64.RawURLEncoding.EncodeToString(sum[:]) + `"`
+
+	h := w.Header()
+	h.Set("ETag", etag)
+	h.Set("Cache-Control", CacheControlRevalidate)
+	if !lastModified.IsZero() {
+		h.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, lastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	h.Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buff); err != nil {
+		return fmt.Errorf("%w: %v", ErrWriteBody, err)
+	}
+	return nil
+}
+
+// notModified проверяет предусловия по RFC 9110: If-None-Match (слабое сравнение)
+// имеет приоритет над If-Modified-Since.
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(ims)
+		return err == nil && !lastModified.Truncate(time.Second).After(t)
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package httpx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+)
+
+func TestWriteConditionalJSON(t *testing.T) {
+	payload := map[string]int{"id": 1}
+	modified := time.Date(2025, time.July, 1, 12, 0, 0, 0, time.UTC)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/subscriptions/1", nil)
+	require.NoError(t, httpx.WriteConditionalJSON(w, r, payload, modified))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"id":1}`, w.Body.String())
+	require.Equal(t, httpx.CacheControlRevalidate, w.Header().Get("Cache-Control"))
+	require.Equal(t, "Tue, 01 Jul 2025 12:00:00 GMT", w.Header().Get("Last-Modified"))
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	cases := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"matching etag", "If-None-Match", etag, http.StatusNotModified},
+		{"etag in list", "If-None-Match", `"other", W/` + etag, http.StatusNotModified},
+		{"wildcard", "If-None-Match", "*", http.StatusNotModified},
+		{"stale etag", "If-None-Match", `"other"`, http.StatusOK},
+		{"not modified since", "If-Modified-Since", "Tue, 01 Jul 2025 12:00:00 GMT", http.StatusNotModified},
+		{"modified since", "If-Modified-Since", "Tue, 01 Jul 2025 11:59:59 GMT", http.StatusOK},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/subscriptions/1", nil)
+		r.Header.Set(tc.header, tc.value)
+		require.NoError(t, httpx.WriteConditionalJSON(w, r, payload, modified))
+
+		require.Equal(t, tc.want, w.Code, tc.name)
+		require.Equal(t, etag, w.Header().Get("ETag"), tc.name)
+		if tc.want == http.StatusNotModified {
+			require.Empty(t, w.Body.String(), tc.name)
+		}
+	}
+}
+
+func TestWriteConditionalJSON_ETagFollowsPayload(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+
+	w1 := httptest.NewRecorder()
+	require.NoError(t, httpx.WriteConditionalJSON(w1, r, []int{1, 2}, time.Time{}))
+	w2 := httptest.NewRecorder()
+	require.NoError(t, httpx.WriteConditionalJSON(w2, r, []int{1}, time.Time{}))
+
+	require.NotEqual(t, w1.Header().Get("ETag"), w2.Header().Get("ETag"))
+	require.Empty(t, w1.Header().Get("Last-Modified"))
+}
@@ -16,18 +16,18 @@ func IsJSON(ct string) bool {
 }
 
 func WriteJSON(w http.ResponseWriter, code int, v any) error {
+	return writeBody(w, code, "application/json", v)
+}
+
+func writeBody(w http.ResponseWriter, code int, contentType string, v any) error {
 	buff, err := json.Marshal(v)
 	if err != nil {
 		return fmt.Errorf("%w: %v", ErrJSONMarshal, err)
 	}
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(code)
 	if _, err := w.Write(buff); err != nil {
 		return fmt.Errorf("%w: %v", ErrWriteBody, err)
 	}
 	return nil
 }
-
-func HttpError(w http.ResponseWriter, code int, message string) error {
-	return WriteJSON(w, code, map[string]string{"error": message})
-}
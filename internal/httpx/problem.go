@@ -0,0 +1,77 @@
+package httpx
+
+import (
+	"net/http"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Стабильные машиночитаемые коды ошибок (поле code в problem+json).
+const (
+	CodeValidationFailed         = "validation_failed"
+	CodeInvalidJSON              = "invalid_json"
+	CodeNotFound                 = "not_found"
+	CodeConflict                 = "conflict"
+	CodeSubscriptionOverlap      = "subscription_overlap"
+	CodeUnsupportedMediaType     = "unsupported_media_type"
+	CodeRequestTooLarge          = "request_too_large"
+	CodeIdempotencyKeyInvalid    = "idempotency_key_invalid"
+	CodeIdempotencyKeyReused     = "idempotency_key_reused"
+	CodeIdempotencyKeyInProgress = "idempotency_key_in_progress"
+	CodeUnauthorized             = "unauthorized"
+	CodeForbidden                = "forbidden"
+	CodeInternal                 = "internal_error"
+)
+
+// FieldError - ошибка конкретного поля запроса.
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Problem - тело ответа об ошибке по RFC 7807.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+func NewProblem(status int, code, detail string) Problem {
+	return Problem{
+		Type:   "urn:subscription-aggregator:problem:" + code,
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// WriteProblem пишет ошибку как application/problem+json, instance - id запроса.
+// Пустой title заполняется из каталога на языке запроса.
+func WriteProblem(w http.ResponseWriter, r *http.Request, p Problem) error {
+	if p.Title == "" {
+		key := i18n.ProblemTitleKey(p.Code)
+		if p.Title = i18n.T(i18n.FromContext(r.Context()), key); p.Title == key {
+			p.Title = http.StatusText(p.Status)
+		}
+	}
+	if p.Instance == "" {
+		p.Instance = RequestIDFromContext(r.Context())
+	}
+	return writeBody(w, p.Status, ProblemContentType, p)
+}
+
+// WriteError пишет ошибку с detail из каталога по ключу detailKey (пустой ключ - без detail).
+func WriteError(w http.ResponseWriter, r *http.Request, status int, code, detailKey string, args ...any) error {
+	var detail string
+	if detailKey != "" {
+		detail = i18n.T(i18n.FromContext(r.Context()), detailKey, args...)
+	}
+	return WriteProblem(w, r, NewProblem(status, code, detail))
+}
@@ -0,0 +1,53 @@
+package httpx_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name      string
+		lang      i18n.Lang
+		status    int
+		code      string
+		detailKey string
+		args      []any
+		wantTitle string
+		wantDet   string
+	}{
+		{name: "заголовок из каталога", lang: i18n.RU, status: http.StatusNotFound, code: httpx.CodeNotFound, wantTitle: "Запись не найдена"},
+		{name: "detail на языке запроса", lang: i18n.EN, status: http.StatusRequestEntityTooLarge, code: httpx.CodeRequestTooLarge, detailKey: i18n.MsgBodyTooLarge, args: []any{4}, wantTitle: "Request body is too large", wantDet: "Request body must not be larger than 4 bytes"},
+		{name: "неизвестный код", lang: i18n.EN, status: http.StatusTeapot, code: "teapot", wantTitle: http.StatusText(http.StatusTeapot)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+			r = r.WithContext(httpx.WithRequestID(i18n.WithLang(r.Context(), tt.lang), "req-1"))
+			w := httptest.NewRecorder()
+
+			require.NoError(t, httpx.WriteError(w, r, tt.status, tt.code, tt.detailKey, tt.args...))
+
+			var p httpx.Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+			require.Equal(t, tt.status, w.Code)
+			require.Equal(t, httpx.ProblemContentType, w.Header().Get("Content-Type"))
+			require.Equal(t, httpx.Problem{
+				Type:     "urn:subscription-aggregator:problem:" + tt.code,
+				Title:    tt.wantTitle,
+				Status:   tt.status,
+				Detail:   tt.wantDet,
+				Instance: "req-1",
+				Code:     tt.code,
+			}, p)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package i18n
+
+// Ключи сообщений об ошибках полей.
+const (
+	MsgFieldRequired       = "field.required"
+	MsgFieldEmpty          = "field.empty"
+	MsgFieldMonthFormat    = "field.month_format"
+	MsgFieldUUID           = "field.uuid"
+	MsgFieldTimestamp      = "field.timestamp"
+	MsgFieldInteger        = "field.integer"
+	MsgFieldBool           = "field.bool"
+	MsgFieldNegative       = "field.negative"
+	MsgFieldBeforeStart    = "field.before_start"
+	MsgFieldLessThan       = "field.less_than"
+	MsgNoFields            = "request.no_fields"
+	MsgInvalidID           = "request.invalid_id"
+	MsgLimitRange          = "request.limit_range"
+	MsgOffsetRange         = "request.offset_range"
+	MsgOverlapMode         = "request.overlap_mode"
+	MsgStatus              = "request.status"
+	MsgSortField           = "request.sort_field"
+	MsgSortDuplicate       = "request.sort_duplicate"
+	MsgQueryTooLong        = "request.query_too_long"
+	MsgTooManyValues       = "request.too_many_values"
+	MsgFieldID             = "field.id"
+	MsgFieldTeamID         = "field.team_not_found"
+	MsgFieldOrganizationID = "field.organization_not_found"
+	MsgRole                = "field.role"
+	MsgGroupBy             = "request.group_by"
+	MsgParticipantShare    = "field.participant_share"
+	MsgBudgetOwner         = "field.budget_owner"
+	MsgBudgetPeriod        = "field.budget_period"
+	MsgForecastMonths      = "request.forecast_months"
+	MsgTrialExclusive      = "field.trial_exclusive"
+	MsgFieldAfterEnd       = "field.after_end"
+	MsgPauseOverlap        = "field.pause_overlap"
+	MsgInvalidInput        = "request.invalid_input"
+	MsgCheckViolation      = "request.check_violation"
+)
+
+// Ключи детальных сообщений problem+json.
+const (
+	MsgSubscriptionNotFound  = "subscription.not_found"
+	MsgSubscriptionOverlap   = "subscription.overlap"
+	MsgExpectedJSON          = "request.expected_json"
+	MsgBodyUnreadable        = "request.body_unreadable"
+	MsgBodyTooLarge          = "request.body_too_large"
+	MsgIdempotencyKeyTooLong = "idempotency.key_too_long"
+	MsgOrganizationNotFound  = "organization.not_found"
+	MsgTeamNotFound          = "team.not_found"
+	MsgMemberNotFound        = "team.member_not_found"
+	MsgAuthRequired          = "auth.required"
+	MsgForbidden             = "auth.forbidden"
+	MsgParticipantNotFound   = "subscription.participant_not_found"
+	MsgBudgetNotFound        = "budget.not_found"
+	MsgPriceChangeNotFound   = "subscription.price_change_not_found"
+)
+
+// ProblemTitleKey - ключ заголовка problem+json для машиночитаемого кода ошибки.
+func ProblemTitleKey(code string) string {
+	return "problem." + code
+}
+
+var catalog = map[Lang]map[string]string{
+	RU: {
+		"problem.validation_failed":           "Ошибка валидации",
+		"problem.invalid_json":                "Некорректный JSON",
+		"problem.not_found":                   "Запись не найдена",
+		"problem.conflict":                    "Конфликт с существующими записями",
+		"problem.subscription_overlap":        "Подписка пересекается с существующими",
+		"problem.unsupported_media_type":      "Неподдерживаемый Content-Type",
+		"problem.request_too_large":           "Тело запроса слишком большое",
+		"problem.idempotency_key_invalid":     "Некорректный Idempotency-Key",
+		"problem.idempotency_key_reused":      "Idempotency-Key уже использован с другим запросом",
+		"problem.idempotency_key_in_progress": "Запрос с таким Idempotency-Key ещё обрабатывается",
+		"problem.internal_error":              "Внутренняя ошибка сервера",
+		"problem.unauthorized":                "Требуется аутентификация",
+		"problem.forbidden":                   "Доступ запрещён",
+
+		MsgFieldRequired:       "%s обязателен",
+		MsgFieldEmpty:          "%s не может быть пустым",
+		MsgFieldMonthFormat:    "%s должен быть в формате MM-YYYY",
+		MsgFieldUUID:           "%s должен быть UUID",
+		MsgFieldTimestamp:      "%s должен быть в формате RFC 3339 (2025-07-01T00:00:00Z)",
+		MsgFieldInteger:        "%s должен быть целым числом",
+		MsgFieldBool:           "%s должен быть true или false",
+		MsgFieldNegative:       "%s не может быть отрицательным",
+		MsgFieldBeforeStart:    "%s не может быть раньше %s",
+		MsgFieldLessThan:       "%s не может быть меньше %s",
+		MsgNoFields:            "необходимо указать хотя бы одно поле для обновления",
+		MsgInvalidID:           "Некорректный ID",
+		MsgLimitRange:          "limit должен быть числом от 1 до 100",
+		MsgOffsetRange:         "offset должен быть числом >= 0",
+		MsgOverlapMode:         "on_overlap должен быть warn или reject",
+		MsgStatus:              "status должен быть active, ended или future",
+		MsgSortField:           "sort: недопустимое поле %q, допустимы %s (с префиксом - для убывания)",
+		MsgSortDuplicate:       "sort: поле %s указано несколько раз",
+		MsgQueryTooLong:        "q не может быть длиннее %d символов",
+		MsgTooManyValues:       "%s: не больше %d значений",
+		MsgFieldID:             "%s должен быть положительным целым числом",
+		MsgFieldTeamID:         "команда team_id не найдена",
+		MsgFieldOrganizationID: "организация organization_id не найдена",
+		MsgRole:                "role должен быть member или admin",
+		MsgGroupBy:             "group_by может быть только team",
+		MsgParticipantShare:    "нужно указать ровно одно из share_weight и fixed_amount",
+		MsgBudgetOwner:         "нужно указать ровно одно из user_id и team_id",
+		MsgBudgetPeriod:        "period должен быть monthly или yearly",
+		MsgForecastMonths:      "months должен быть числом от 1 до %d",
+		MsgTrialExclusive:      "нужно указать не больше одного из trial_end и trial_months",
+		MsgFieldAfterEnd:       "%s не может быть позже %s",
+		MsgPauseOverlap:        "пауза пересекается с существующей паузой подписки",
+		MsgInvalidInput:        "Значение в запросе имеет некорректный формат",
+		MsgCheckViolation:      "Данные нарушают ограничения хранилища",
+
+		MsgSubscriptionNotFound:  "Подписка не найдена",
+		MsgSubscriptionOverlap:   "Подписка пересекается с существующими (id: %s)",
+		MsgExpectedJSON:          "Ожидается Content-Type: application/json",
+		MsgBodyUnreadable:        "Не удалось прочитать тело запроса",
+		MsgBodyTooLarge:          "Тело запроса не может быть больше %d байт",
+		MsgIdempotencyKeyTooLong: "Idempotency-Key не может быть длиннее 255 символов",
+		MsgOrganizationNotFound:  "Организация не найдена",
+		MsgTeamNotFound:          "Команда не найдена",
+		MsgMemberNotFound:        "Пользователь не состоит в команде",
+		MsgAuthRequired:          "Заголовок X-User-ID с UUID пользователя обязателен",
+		MsgForbidden:             "Недостаточно прав для операции",
+		MsgParticipantNotFound:   "Пользователь не участвует в подписке",
+		MsgBudgetNotFound:        "Бюджет не найден",
+		MsgPriceChangeNotFound:   "Изменение цены с этого месяца не запланировано",
+	},
+	EN: {
+		"problem.validation_failed":           "Validation failed",
+		"problem.invalid_json":                "Invalid JSON",
+		"problem.not_found":                   "Resource not found",
+		"problem.conflict":                    "Conflict with existing records",
+		"problem.subscription_overlap":        "Subscription overlaps existing ones",
+		"problem.unsupported_media_type":      "Unsupported Content-Type",
+		"problem.request_too_large":           "Request body is too large",
+		"problem.idempotency_key_invalid":     "Invalid Idempotency-Key",
+		"problem.idempotency_key_reused":      "Idempotency-Key was already used with a different request",
+		"problem.idempotency_key_in_progress": "A request with this Idempotency-Key is still being processed",
+		"problem.internal_error":              "Internal server error",
+		"problem.unauthorized":                "Authentication required",
+		"problem.forbidden":                   "Access denied",
+
+		MsgFieldRequired:       "%s is required",
+		MsgFieldEmpty:          "%s must not be empty",
+		MsgFieldMonthFormat:    "%s must be in MM-YYYY format",
+		MsgFieldUUID:           "%s must be a UUID",
+		MsgFieldTimestamp:      "%s must be an RFC 3339 timestamp (2025-07-01T00:00:00Z)",
+		MsgFieldInteger:        "%s must be an integer",
+		MsgFieldBool:           "%s must be true or false",
+		MsgFieldNegative:       "%s must not be negative",
+		MsgFieldBeforeStart:    "%s must not be before %s",
+		MsgFieldLessThan:       "%s must not be less than %s",
+		MsgNoFields:            "at least one field must be provided for update",
+		MsgInvalidID:           "Invalid ID",
+		MsgLimitRange:          "limit must be a number from 1 to 100",
+		MsgOffsetRange:         "offset must be a number >= 0",
+		MsgOverlapMode:         "on_overlap must be warn or reject",
+		MsgStatus:              "status must be active, ended or future",
+		MsgSortField:           "sort: invalid field %q, allowed: %s (prefix with - for descending)",
+		MsgSortDuplicate:       "sort: field %s is specified more than once",
+		MsgQueryTooLong:        "q must not be longer than %d characters",
+		MsgTooManyValues:       "%s: at most %d values are allowed",
+		MsgFieldID:             "%s must be a positive integer",
+		MsgFieldTeamID:         "team team_id not found",
+		MsgFieldOrganizationID: "organization organization_id not found",
+		MsgRole:                "role must be member or admin",
+		MsgGroupBy:             "group_by can only be team",
+		MsgParticipantShare:    "exactly one of share_weight and fixed_amount must be set",
+		MsgBudgetOwner:         "exactly one of user_id and team_id must be set",
+		MsgBudgetPeriod:        "period must be monthly or yearly",
+		MsgForecastMonths:      "months must be a number from 1 to %d",
+		MsgTrialExclusive:      "specify at most one of trial_end and trial_months",
+		MsgFieldAfterEnd:       "%s must not be after %s",
+		MsgPauseOverlap:        "the pause overlaps an existing pause of the subscription",
+		MsgInvalidInput:        "A value in the request has an invalid format",
+		MsgCheckViolation:      "The data violates storage constraints",
+
+		MsgSubscriptionNotFound:  "Subscription not found",
+		MsgSubscriptionOverlap:   "Subscription overlaps existing ones (id: %s)",
+		MsgExpectedJSON:          "Expected Content-Type: application/json",
+		MsgBodyUnreadable:        "Failed to read request body",
+		MsgBodyTooLarge:          "Request body must not be larger than %d bytes",
+		MsgIdempotencyKeyTooLong: "Idempotency-Key must not be longer than 255 characters",
+		MsgOrganizationNotFound:  "Organization not found",
+		MsgTeamNotFound:          "Team not found",
+		MsgMemberNotFound:        "The user is not a member of the team",
+		MsgAuthRequired:          "The X-User-ID header with the user's UUID is required",
+		MsgForbidden:             "Not enough permissions for the operation",
+		MsgParticipantNotFound:   "The user is not a participant of the subscription",
+		MsgBudgetNotFound:        "Budget not found",
+		MsgPriceChangeNotFound:   "No price change is scheduled for this month",
+	},
+}
@@ -0,0 +1,40 @@
+package i18n_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]i18n.Lang{
+		"":                         i18n.RU,
+		"en":                       i18n.EN,
+		"en-US,en;q=0.9":           i18n.EN,
+		"ru-RU":                    i18n.RU,
+		"de-DE,en;q=0.5":           i18n.EN,
+		"ru;q=0.3, en;q=0.8":       i18n.EN,
+		"en;q=0, ru":               i18n.RU,
+		"fr, de":                   i18n.RU,
+		"en;q=abc":                 i18n.RU,
+		"EN-gb;q=0.7, ru-RU;q=0.6": i18n.EN,
+	}
+	for header, want := range cases {
+		assert.Equal(t, want, i18n.Parse(header), "Accept-Language: %q", header)
+	}
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "price не может быть отрицательным", i18n.T(i18n.RU, i18n.MsgFieldNegative, "price"))
+	assert.Equal(t, "price must not be negative", i18n.T(i18n.EN, i18n.MsgFieldNegative, "price"))
+	assert.Equal(t, "Подписка не найдена", i18n.T("de", i18n.MsgSubscriptionNotFound))
+	assert.Equal(t, "unknown.key", i18n.T(i18n.EN, "unknown.key"))
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, i18n.Default, i18n.FromContext(context.Background()))
+	assert.Equal(t, i18n.EN, i18n.FromContext(i18n.WithLang(context.Background(), i18n.EN)))
+}
@@ -0,0 +1,54 @@
+package lru
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := New(2)
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), 0))
+	_, ok, _ := c.Get(ctx, "a")
+	require.True(t, ok)
+
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), 0))
+
+	_, ok, _ = c.Get(ctx, "b")
+	require.False(t, ok)
+	v, ok, _ := c.Get(ctx, "a")
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), v)
+	require.Equal(t, 2, c.Len())
+}
+
+func TestCache_TTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+	c := New(10)
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set(ctx, "k", []byte("v"), time.Minute))
+	_, ok, _ := c.Get(ctx, "k")
+	require.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok, _ = c.Get(ctx, "k")
+	require.False(t, ok)
+	require.Equal(t, 0, c.Len())
+}
+
+func TestCache_Delete(t *testing.T) {
+	ctx := context.Background()
+	c := New(10)
+
+	require.NoError(t, c.Set(ctx, "k", []byte("v"), 0))
+	require.NoError(t, c.Delete(ctx, "k"))
+	_, ok, _ := c.Get(ctx, "k")
+	require.False(t, ok)
+}
@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ infra.Notifier = (*Log)(nil)
+
+// Log пишет уведомления в лог; используется, если webhook не настроен.
+type Log struct {
+	logger *zap.Logger
+}
+
+func NewLog(logger *zap.Logger) *Log {
+	return &Log{logger: logger.With(zap.String("component", "infra.Notifier(Log)"))}
+}
+
+func (l *Log) NotifyBudget(_ context.Context, alert models.BudgetAlert) error {
+	fields := []zap.Field{
+		zap.Int("budget_id", alert.Budget.ID),
+		zap.String("period", alert.Budget.Period),
+		zap.Int("amount", alert.Budget.Amount),
+		zap.Int("spent", alert.Spent),
+		zap.Int("threshold", alert.Threshold),
+		zap.String("period_start", alert.PeriodStart.Format("01-2006")),
+	}
+	if alert.Budget.UserID != nil {
+		fields = append(fields, zap.String("user_id", *alert.Budget.UserID))
+	}
+	if alert.Budget.TeamID != nil {
+		fields = append(fields, zap.Int("team_id", *alert.Budget.TeamID))
+	}
+	l.logger.Warn("Расходы достигли порога бюджета", fields...)
+	return nil
+}
@@ -0,0 +1,79 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ infra.Notifier = (*Webhook)(nil)
+
+// Webhook отправляет уведомление POST запросом с JSON телом; ответ не 2xx считается ошибкой.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhook(url string, timeout time.Duration) *Webhook {
+	return &Webhook{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+type budgetAlertPayload struct {
+	Event       string  `json:"event"`
+	BudgetID    int     `json:"budget_id"`
+	UserID      *string `json:"user_id,omitempty"`
+	TeamID      *int    `json:"team_id,omitempty"`
+	Period      string  `json:"period"`
+	Category    string  `json:"category,omitempty"`
+	ServiceName string  `json:"service_name,omitempty"`
+	Amount      int     `json:"amount"`
+	Spent       int     `json:"spent"`
+	Threshold   int     `json:"threshold"`
+	PeriodStart string  `json:"period_start"`
+	PeriodEnd   string  `json:"period_end"`
+}
+
+func (w *Webhook) NotifyBudget(ctx context.Context, alert models.BudgetAlert) error {
+	body, err := json.Marshal(budgetAlertPayload{
+		Event:       "budget.threshold_reached",
+		BudgetID:    alert.Budget.ID,
+		UserID:      alert.Budget.UserID,
+		TeamID:      alert.Budget.TeamID,
+		Period:      alert.Budget.Period,
+		Category:    alert.Budget.Category,
+		ServiceName: alert.Budget.ServiceName,
+		Amount:      alert.Budget.Amount,
+		Spent:       alert.Spent,
+		Threshold:   alert.Threshold,
+		PeriodStart: alert.PeriodStart.Format("01-2006"),
+		PeriodEnd:   alert.PeriodEnd.Format("01-2006"),
+	})
+	if err != nil {
+		return fmt.Errorf("webhook NotifyBudget(): %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook NotifyBudget(): %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook NotifyBudget(): %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook NotifyBudget(): неожиданный статус %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/infra/notifier"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func TestWebhook_NotifyBudget_OK(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	userID := "u-1"
+	alert := models.BudgetAlert{
+		Budget:      models.Budget{ID: 7, UserID: &userID, Period: models.BudgetMonthly, Amount: 1000},
+		Threshold:   80,
+		PeriodStart: time.Date(2025, time.July, 1, 0, 0, 0, 0, time.Local),
+		PeriodEnd:   time.Date(2025, time.July, 1, 0, 0, 0, 0, time.Local),
+		Spent:       850,
+	}
+
+	err := notifier.NewWebhook(srv.URL, time.Second).NotifyBudget(context.Background(), alert)
+	require.NoError(t, err)
+	require.Equal(t, "budget.threshold_reached", got["event"])
+	require.EqualValues(t, 7, got["budget_id"])
+	require.EqualValues(t, 80, got["threshold"])
+	require.EqualValues(t, 850, got["spent"])
+	require.Equal(t, "07-2025", got["period_start"])
+}
+
+func TestWebhook_NotifyBudget_ErrStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := notifier.NewWebhook(srv.URL, time.Second).NotifyBudget(context.Background(), models.BudgetAlert{})
+	require.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ infra.Budgets = (*PostgresDB)(nil)
+
+const budgetColumns = `id, user_id, team_id, period, amount, category, service_name, created_at`
+
+func (db *PostgresDB) CreateBudget(ctx context.Context, data models.Budget) (int, error) {
+	const query = `
+		INSERT INTO budgets (user_id, team_id, period, amount, category, service_name)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id;
+	`
+	var id int
+
+	if err := db.retry(ctx, "create_budget", false, func() error {
+		return db.pool.QueryRow(ctx, query,
+			data.UserID, data.TeamID, data.Period, data.Amount, data.Category, data.ServiceName,
+		).Scan(&id)
+	}); err != nil {
+		return -1, fmt.Errorf("postgres CreateBudget(): %w", mapError(err))
+	}
+
+	return id, nil
+}
+
+func (db *PostgresDB) GetBudget(ctx context.Context, id int) (models.Budget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM budgets WHERE id = $1;`
+	var data models.Budget
+
+	if err := db.retry(ctx, "get_budget", true, func() error {
+		return db.read(ctx, func(pool *pgxpool.Pool) error {
+			rows, err := pool.Query(ctx, query, id)
+			if err != nil {
+				return err
+			}
+			data, err = pgx.CollectExactlyOneRow(rows, scanBudget)
+			return err
+		})
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Budget{}, infra.ErrNotFound
+		}
+		return models.Budget{}, fmt.Errorf("postgres GetBudget(): %w", mapError(err))
+	}
+
+	return data, nil
+}
+
+func (db *PostgresDB) ListBudgets(ctx context.Context, afterID, limit int) ([]models.Budget, error) {
+	query := `SELECT ` + budgetColumns + ` FROM budgets WHERE id > $1 ORDER BY id LIMIT $2;`
+	var data []models.Budget
+
+	if err := db.retry(ctx, "list_budgets", true, func() error {
+		return db.read(ctx, func(pool *pgxpool.Pool) error {
+			rows, err := pool.Query(ctx, query, afterID, limit)
+			if err != nil {
+				return err
+			}
+			data, err = pgx.CollectRows(rows, scanBudget)
+			return err
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("postgres ListBudgets(): %w", mapError(err))
+	}
+
+	return data, nil
+}
+
+func (db *PostgresDB) ClaimAlert(ctx context.Context, budgetID int, periodStart time.Time, threshold int) (bool, error) {
+	const query = `
+		INSERT INTO budget_alerts (budget_id, period_start, threshold)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING;
+	`
+
+	// Повтор применённого INSERT вернул бы false и потерял уведомление, поэтому повторяем только неприменённый.
+	var ct pgconn.CommandTag
+	err := db.retry(ctx, "claim_alert", false, func() (err error) {
+		ct, err = db.pool.Exec(ctx, query, budgetID, periodStart, threshold)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("postgres ClaimAlert(): %w", mapError(err))
+	}
+	return ct.RowsAffected() == 1, nil
+}
+
+func (db *PostgresDB) ReleaseAlert(ctx context.Context, budgetID int, periodStart time.Time, threshold int) error {
+	const query = `
+		DELETE FROM budget_alerts WHERE budget_id = $1 AND period_start = $2 AND threshold = $3;
+	`
+
+	if err := db.retry(ctx, "release_alert", true, func() error {
+		_, err := db.pool.Exec(ctx, query, budgetID, periodStart, threshold)
+		return err
+	}); err != nil {
+		return fmt.Errorf("postgres ReleaseAlert(): %w", mapError(err))
+	}
+	return nil
+}
+
+func scanBudget(row pgx.CollectableRow) (models.Budget, error) {
+	var data models.Budget
+	err := row.Scan(
+		&data.ID, &data.UserID, &data.TeamID, &data.Period, &data.Amount,
+		&data.Category, &data.ServiceName, &data.CreatedAt,
+	)
+	return data, err
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+)
+
+// SQLSTATE коды ошибок, которые вызваны данными запроса, а не состоянием БД.
+const (
+	codeInvalidTextRepresentation = "22P02"
+	codeCheckViolation            = "23514"
+	codeUniqueViolation           = "23505"
+	codeForeignKeyViolation       = "23503"
+	codeSerializationFailure      = "40001"
+)
+
+// mapError заменяет известные ошибки Postgres на типизированные ошибки infra,
+// остальные возвращает как есть.
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	var kind error
+	switch pgErr.Code {
+	case codeInvalidTextRepresentation:
+		kind = infra.ErrInvalidInput
+	case codeCheckViolation:
+		kind = infra.ErrCheckViolation
+	case codeUniqueViolation:
+		kind = infra.ErrUniqueViolation
+	case codeForeignKeyViolation:
+		kind = infra.ErrForeignKey
+	case codeSerializationFailure:
+		kind = infra.ErrSerialization
+	default:
+		return err
+	}
+
+	return &infra.ConstraintError{
+		Err:        kind,
+		Constraint: pgErr.ConstraintName,
+		Detail:     pgErr.Message,
+	}
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+)
+
+func TestMapError(t *testing.T) {
+	cases := map[string]error{
+		codeInvalidTextRepresentation: infra.ErrInvalidInput,
+		codeCheckViolation:            infra.ErrCheckViolation,
+		codeUniqueViolation:           infra.ErrUniqueViolation,
+		codeForeignKeyViolation:       infra.ErrForeignKey,
+		codeSerializationFailure:      infra.ErrSerialization,
+	}
+	for code, want := range cases {
+		pgErr := &pgconn.PgError{Code: code, ConstraintName: "subscriptions_price_check", Message: "boom"}
+
+		err := mapError(fmt.Errorf("exec: %w", pgErr))
+		require.ErrorIs(t, err, want, code)
+
+		var constraintErr *infra.ConstraintError
+		require.ErrorAs(t, err, &constraintErr)
+		require.Equal(t, "subscriptions_price_check", constraintErr.Constraint)
+	}
+}
+
+func TestMapError_PassThrough(t *testing.T) {
+	plain := errors.New("connection refused")
+	require.Same(t, plain, mapError(plain))
+
+	other := &pgconn.PgError{Code: "42P01"}
+	require.Same(t, other, mapError(other))
+}
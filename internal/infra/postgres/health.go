@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+)
+
+var _ infra.HealthChecker = (*PostgresDB)(nil)
+
+// requiredTables - таблицы, которые должны быть созданы миграциями.
+var requiredTables = []string{
+	"subscriptions",
+	"idempotency_keys",
+	"organizations",
+	"teams",
+	"team_members",
+	"subscription_participants",
+	"budgets",
+	"budget_alerts",
+	"subscription_price_changes",
+	"subscription_pauses",
+}
+
+func (db *PostgresDB) Ping(ctx context.Context) error {
+	if err := db.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("postgres Ping(): %w", err)
+	}
+	return nil
+}
+
+func (db *PostgresDB) CheckMigrations(ctx context.Context) error {
+	const query = `
+		SELECT t
+		FROM unnest($1::text[]) AS t
+		WHERE to_regclass(t) IS NULL;
+	`
+
+	rows, err := db.pool.Query(ctx, query, requiredTables)
+	if err != nil {
+		return fmt.Errorf("postgres CheckMigrations(): %w", err)
+	}
+	defer rows.Close()
+
+	var missing []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return fmt.Errorf("postgres CheckMigrations(), rows.Scan(): %w", err)
+		}
+		missing = append(missing, table)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("postgres CheckMigrations(), rows.Err(): %w", err)
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("postgres CheckMigrations(): отсутствуют таблицы: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ infra.IdempotencyStore = (*PostgresDB)(nil)
+
+func (db *PostgresDB) Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (models.IdempotencyRecord, bool, error) {
+	// Истёкшая запись считается свободной: upsert забирает ключ себе вместо удаления на каждом запросе.
+	const insertQuery = `
+		INSERT INTO idempotency_keys (key, request_hash, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			content_type = NULL,
+			response_body = NULL,
+			created_at = now(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < now()
+		RETURNING key;
+	`
+	const selectQuery = `
+		SELECT key, request_hash, COALESCE(status_code, 0), COALESCE(content_type, ''), response_body, expires_at
+		FROM idempotency_keys
+		WHERE key = $1;
+	`
+
+	expiresAt := time.Now().Add(ttl)
+	var inserted string
+	err := db.pool.QueryRow(ctx, insertQuery, key, requestHash, expiresAt).Scan(&inserted)
+	switch {
+	case err == nil:
+		return models.IdempotencyRecord{Key: key, RequestHash: requestHash, ExpiresAt: expiresAt}, true, nil
+	case !errors.Is(err, pgx.ErrNoRows):
+		return models.IdempotencyRecord{}, false, fmt.Errorf("postgres Reserve(), insert: %w", err)
+	}
+
+	var rec models.IdempotencyRecord
+	if err := db.pool.QueryRow(ctx, selectQuery, key).Scan(
+		&rec.Key, &rec.RequestHash, &rec.StatusCode, &rec.ContentType, &rec.ResponseBody, &rec.ExpiresAt,
+	); err != nil {
+		return models.IdempotencyRecord{}, false, fmt.Errorf("postgres Reserve(), select: %w", err)
+	}
+
+	return rec, false, nil
+}
+
+func (db *PostgresDB) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	const query = `
+		UPDATE idempotency_keys
+		SET status_code = $1, content_type = $2, response_body = $3
+		WHERE key = $4;
+	`
+
+	ct, err := db.pool.Exec(ctx, query, statusCode, contentType, body, key)
+	if err != nil {
+		return fmt.Errorf("postgres Complete(): %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return infra.ErrNotFound
+	}
+	return nil
+}
+
+func (db *PostgresDB) Release(ctx context.Context, key string) error {
+	const query = `
+		DELETE FROM idempotency_keys WHERE key = $1;
+	`
+
+	if _, err := db.pool.Exec(ctx, query, key); err != nil {
+		return fmt.Errorf("postgres Release(): %w", err)
+	}
+	return nil
+}
+
+func (db *PostgresDB) PurgeExpired(ctx context.Context) (int64, error) {
+	const query = `
+		DELETE FROM idempotency_keys WHERE expires_at < now();
+	`
+
+	ct, err := db.pool.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("postgres PurgeExpired(): %w", err)
+	}
+	return ct.RowsAffected(), nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const collectTimeout = 2 * time.Second
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// Collector отдаёт статистику pgxpool и бизнес-метрики, вычисляемые запросом к БД в момент сбора.
+type Collector struct {
+	db *PostgresDB
+
+	acquiredConns   *prometheus.Desc
+	idleConns       *prometheus.Desc
+	totalConns      *prometheus.Desc
+	acquireCount    *prometheus.Desc
+	acquireDuration *prometheus.Desc
+	activeSubs      *prometheus.Desc
+}
+
+func NewCollector(db *PostgresDB) *Collector {
+	const ns = "subscription_aggregator"
+	return &Collector{
+		db:              db,
+		acquiredConns:   prometheus.NewDesc(ns+"_pgxpool_acquired_conns", "Количество занятых соединений пула.", nil, nil),
+		idleConns:       prometheus.NewDesc(ns+"_pgxpool_idle_conns", "Количество простаивающих соединений пула.", nil, nil),
+		totalConns:      prometheus.NewDesc(ns+"_pgxpool_total_conns", "Общее количество соединений пула.", nil, nil),
+		acquireCount:    prometheus.NewDesc(ns+"_pgxpool_acquire_count_total", "Количество успешных получений соединения из пула.", nil, nil),
+		acquireDuration: prometheus.NewDesc(ns+"_pgxpool_acquire_duration_seconds_total", "Суммарное время ожидания соединения из пула.", nil, nil),
+		activeSubs:      prometheus.NewDesc(ns+"_active_subscriptions", "Количество подписок, действующих в текущем месяце.", nil, nil),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.activeSubs
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.db.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	active, err := c.db.countActive(ctx)
+	if err != nil {
+		c.db.logger.Warn("Не удалось посчитать активные подписки для метрик",
+			zap.String("component", "infra.Database(PostgresDB)"),
+			zap.Error(err),
+		)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.activeSubs, prometheus.GaugeValue, float64(active))
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCollector_PoolStatsWithoutDatabase(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	db := &PostgresDB{pool: newLazyPool(t), logger: zap.New(core)}
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(NewCollector(db))
+
+	expected := `
+# HELP subscription_aggregator_pgxpool_acquired_conns Количество занятых соединений пула.
+# TYPE subscription_aggregator_pgxpool_acquired_conns gauge
+subscription_aggregator_pgxpool_acquired_conns 0
+# HELP subscription_aggregator_pgxpool_total_conns Общее количество соединений пула.
+# TYPE subscription_aggregator_pgxpool_total_conns gauge
+subscription_aggregator_pgxpool_total_conns 0
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"subscription_aggregator_pgxpool_acquired_conns",
+		"subscription_aggregator_pgxpool_total_conns",
+	))
+
+	// БД недоступна: активные подписки не отдаются, чтобы не показывать ложный ноль.
+	n, err := testutil.GatherAndCount(reg, "subscription_aggregator_active_subscriptions")
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.NotZero(t, logs.FilterMessage("Не удалось посчитать активные подписки для метрик").Len())
+}
+
+func TestCollector_Lint(t *testing.T) {
+	db := &PostgresDB{pool: newLazyPool(t), logger: zap.NewNop()}
+
+	problems, err := testutil.CollectAndLint(NewCollector(db))
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
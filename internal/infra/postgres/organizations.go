@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ infra.Organizations = (*PostgresDB)(nil)
+
+func (db *PostgresDB) CreateOrganization(ctx context.Context, data models.Organization) (int, error) {
+	const query = `
+		INSERT INTO organizations (name, owner_id)
+		VALUES ($1, $2)
+		RETURNING id;
+	`
+	var id int
+
+	if err := db.retry(ctx, "create_organization", false, func() error {
+		return db.pool.QueryRow(ctx, query, data.Name, data.OwnerID).Scan(&id)
+	}); err != nil {
+		return -1, fmt.Errorf("postgres CreateOrganization(): %w", mapError(err))
+	}
+
+	return id, nil
+}
+
+func (db *PostgresDB) GetOrganization(ctx context.Context, id int) (models.Organization, error) {
+	const query = `
+		SELECT id, name, owner_id, created_at
+		FROM organizations
+		WHERE id = $1;
+	`
+	var data models.Organization
+
+	if err := db.retry(ctx, "get_organization", true, func() error {
+		return db.read(ctx, func(pool *pgxpool.Pool) error {
+			return pool.QueryRow(ctx, query, id).Scan(&data.ID, &data.Name, &data.OwnerID, &data.CreatedAt)
+		})
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Organization{}, infra.ErrNotFound
+		}
+		return models.Organization{}, fmt.Errorf("postgres GetOrganization(): %w", mapError(err))
+	}
+
+	return data, nil
+}
+
+func (db *PostgresDB) CreateTeam(ctx context.Context, data models.Team) (int, error) {
+	const query = `
+		INSERT INTO teams (organization_id, name)
+		VALUES ($1, $2)
+		RETURNING id;
+	`
+	var id int
+
+	if err := db.retry(ctx, "create_team", false, func() error {
+		return db.pool.QueryRow(ctx, query, data.OrganizationID, data.Name).Scan(&id)
+	}); err != nil {
+		return -1, fmt.Errorf("postgres CreateTeam(): %w", mapError(err))
+	}
+
+	return id, nil
+}
+
+func (db *PostgresDB) GetTeam(ctx context.Context, id int) (models.Team, error) {
+	const query = `
+		SELECT id, organization_id, name, created_at
+		FROM teams
+		WHERE id = $1;
+	`
+	var data models.Team
+
+	if err := db.retry(ctx, "get_team", true, func() error {
+		return db.read(ctx, func(pool *pgxpool.Pool) error {
+			return pool.QueryRow(ctx, query, id).Scan(&data.ID, &data.OrganizationID, &data.Name, &data.CreatedAt)
+		})
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Team{}, infra.ErrNotFound
+		}
+		return models.Team{}, fmt.Errorf("postgres GetTeam(): %w", mapError(err))
+	}
+
+	return data, nil
+}
+
+func (db *PostgresDB) ListTeams(ctx context.Context, organizationID int) ([]models.Team, error) {
+	const query = `
+		SELECT id, organization_id, name, created_at
+		FROM teams
+		WHERE organization_id = $1
+		ORDER BY id;
+	`
+	var data []models.Team
+
+	if err := db.retry(ctx, "list_teams", true, func() error {
+		return db.read(ctx, func(pool *pgxpool.Pool) error {
+			rows, err := pool.Query(ctx, query, organizationID)
+			if err != nil {
+				return err
+			}
+			data, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Team, error) {
+				var t models.Team
+				err := row.Scan(&t.ID, &t.OrganizationID, &t.Name, &t.CreatedAt)
+				return t, err
+			})
+			return err
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("postgres ListTeams(): %w", mapError(err))
+	}
+
+	return data, nil
+}
+
+func (db *PostgresDB) SetMember(ctx context.Context, data models.TeamMember) error {
+	const query = `
+		INSERT INTO team_members (team_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (team_id, user_id) DO UPDATE SET role = EXCLUDED.role;
+	`
+
+	// Upsert выставляет абсолютное значение, поэтому повтор безопасен.
+	if err := db.retry(ctx, "set_member", true, func() error {
+		_, err := db.pool.Exec(ctx, query, data.TeamID, data.UserID, data.Role)
+		return err
+	}); err != nil {
+		return fmt.Errorf("postgres SetMember(): %w", mapError(err))
+	}
+
+	return nil
+}
+
+func (db *PostgresDB) RemoveMember(ctx context.Context, teamID int, userID string) error {
+	const query = `
+		DELETE FROM team_members WHERE team_id = $1 AND user_id = $2;
+	`
+
+	var ct pgconn.CommandTag
+	err := db.retry(ctx, "remove_member", false, func() (err error) {
+		ct, err = db.pool.Exec(ctx, query, teamID, userID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("postgres RemoveMember(): %w", mapError(err))
+	}
+	if ct.RowsAffected() == 0 {
+		return infra.ErrNotFound
+	}
+	return nil
+}
+
+func (db *PostgresDB) ListMembers(ctx context.Context, teamID int) ([]models.TeamMember, error) {
+	const query = `
+		SELECT team_id, user_id, role
+		FROM team_members
+		WHERE team_id = $1
+		ORDER BY user_id;
+	`
+	var data []models.TeamMember
+
+	if err := db.retry(ctx, "list_members", true, func() error {
+		return db.read(ctx, func(pool *pgxpool.Pool) error {
+			return collectMembers(ctx, pool, &data, query, teamID)
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("postgres ListMembers(): %w", mapError(err))
+	}
+
+	return data, nil
+}
+
+// UserTeams читает членство всегда с primary: по нему проверяются права,
+// и исключение из команды должно действовать сразу, без задержки реплики.
+func (db *PostgresDB) UserTeams(ctx context.Context, userID string) ([]models.TeamMember, error) {
+	const query = `
+		SELECT team_id, user_id, role
+		FROM team_members
+		WHERE user_id = $1
+		ORDER BY team_id;
+	`
+	var data []models.TeamMember
+
+	if err := db.retry(ctx, "user_teams", true, func() error {
+		return collectMembers(ctx, db.pool, &data, query, userID)
+	}); err != nil {
+		return nil, fmt.Errorf("postgres UserTeams(): %w", mapError(err))
+	}
+
+	return data, nil
+}
+
+func collectMembers(ctx context.Context, pool *pgxpool.Pool, dst *[]models.TeamMember, query string, args ...any) error {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	*dst, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.TeamMember, error) {
+		var m models.TeamMember
+		err := row.Scan(&m.TeamID, &m.UserID, &m.Role)
+		return m, err
+	})
+	return err
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 
@@ -18,14 +20,56 @@ import (
 var _ infra.Database = (*PostgresDB)(nil)
 
 type PostgresDB struct {
-	pool   *pgxpool.Pool
-	logger *zap.Logger
+	pool        *pgxpool.Pool
+	replica     *replica
+	logger      *zap.Logger
+	retryPolicy retryPolicy
 }
 
-func New(cfg config.PostgresConfig, log *zap.Logger) (infra.Database, error) {
+func New(cfg config.PostgresConfig, log *zap.Logger) (*PostgresDB, error) {
+	pool, err := newPool(cfg, cfg.Host, cfg.Port)
+	if err != nil {
+		return nil, err
+	}
+
+	pingCtx, cancel := context.WithTimeout(context.Background(), cfg.PingTimeout)
+	defer cancel()
+	if err := pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("postgres New() -> Ping: %w", err)
+	}
+
+	log.Info("Postgres Pool инициализирован",
+		zap.String("component", "infra.Database(PostgresDB)"),
+		zap.String("host", cfg.Host),
+		zap.String("db", cfg.DBName),
+		zap.Int32("maxConns", pool.Config().MaxConns),
+	)
+
+	db := &PostgresDB{pool: pool, logger: log, retryPolicy: newRetryPolicy(cfg)}
+
+	if cfg.ReplicaHost != "" {
+		replicaPool, err := newPool(cfg, cfg.ReplicaHost, cfg.ReplicaPort)
+		if err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("postgres New() -> replica: %w", err)
+		}
+		db.startReplica(replicaPool, cfg.ReplicaCheckPeriod, cfg.PingTimeout)
+
+		log.Info("Postgres реплика для чтения подключена",
+			zap.String("component", "infra.Database(PostgresDB)"),
+			zap.String("host", cfg.ReplicaHost),
+			zap.Bool("healthy", db.replica.healthy.Load()),
+		)
+	}
+
+	return db, nil
+}
+
+func newPool(cfg config.PostgresConfig, host, port string) (*pgxpool.Pool, error) {
 	dsn := fmt.Sprintf(
 		"postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DBName, cfg.SSLMode,
+		cfg.User, cfg.Password, host, port, cfg.DBName, cfg.SSLMode,
 	)
 
 	poolCfg, err := pgxpool.ParseConfig(dsn)
@@ -36,30 +80,22 @@ func New(cfg config.PostgresConfig, log *zap.Logger) (infra.Database, error) {
 	poolCfg.MaxConns = int32(cfg.MaxConns)
 	poolCfg.MaxConnLifetime = cfg.MaxConnTTL
 	poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+	poolCfg.ConnConfig.Tracer = queryTracer{}
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("pgxpool.NewWithConfig: %w", err)
 	}
-
-	pingCtx, cancel := context.WithTimeout(context.Background(), cfg.PingTimeout)
-	defer cancel()
-	if err := pool.Ping(pingCtx); err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("postgres New() -> Ping: %w", err)
-	}
-
-	log.Info("Postgres Pool инициализирован",
-		zap.String("component", "infra.Database(PostgresDB)"),
-		zap.String("host", cfg.Host),
-		zap.String("db", cfg.DBName),
-		zap.Int32("maxConns", poolCfg.MaxConns),
-	)
-
-	return &PostgresDB{pool: pool, logger: log}, nil
+	return pool, nil
 }
 
 func (db *PostgresDB) Close() {
+	if db.replica != nil {
+		db.replica.close()
+		db.logger.Info("Postgres реплика закрыта",
+			zap.String("component", "infra.Database(PostgresDB)"),
+		)
+	}
 	if db.pool != nil {
 		db.logger.Info("Postgres Pool закрыт",
 			zap.String("component", "infra.Database(PostgresDB)"),
@@ -70,36 +106,43 @@ func (db *PostgresDB) Close() {
 
 func (db *PostgresDB) Create(ctx context.Context, data models.Subscription) (int, error) {
 	const query = `
-		INSERT INTO subscriptions (service_name, price, user_id, start_date, end_date)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO subscriptions (service_name, price, user_id, start_date, end_date, team_id, category, trial_end, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now(), now())
 		RETURNING id;
 	`
 	var id int
 
-	if err := db.pool.QueryRow(ctx, query,
-		data.ServiceName, data.Price, data.UserID, data.StartDate, data.EndDate,
-	).Scan(&id); err != nil {
-		return -1, fmt.Errorf("postgres Create(): %w", err)
+	// INSERT повторяется, только если он гарантированно не был применён.
+	if err := db.retry(ctx, "create", false, func() error {
+		return db.pool.QueryRow(ctx, query,
+			data.ServiceName, data.Price, data.UserID, data.StartDate, data.EndDate, data.TeamID, data.Category, data.TrialEnd,
+		).Scan(&id)
+	}); err != nil {
+		return -1, fmt.Errorf("postgres Create(): %w", mapError(err))
 	}
 
 	return id, nil
 }
 
 func (db *PostgresDB) GetByID(ctx context.Context, id int) (models.Subscription, error) {
-	const query = `
-		SELECT id, service_name, price, user_id, start_date, end_date
-		FROM subscriptions
-		WHERE id = $1;
-	`
 	var data models.Subscription
 
-	if err := db.pool.QueryRow(ctx, query, id).Scan(
-		&data.ID, &data.ServiceName, &data.Price, &data.UserID, &data.StartDate, &data.EndDate,
-	); err != nil {
+	// Подписка и её дочерние записи читаются одним пакетом за один запрос к базе.
+	if err := db.retry(ctx, "get_by_id", true, func() error {
+		return db.read(ctx, func(pool *pgxpool.Pool) error {
+			subs := []models.Subscription{{ID: id}}
+			batch := getByIDBatch(subs)
+			if err := pool.SendBatch(ctx, batch).Close(); err != nil {
+				return err
+			}
+			data = subs[0]
+			return nil
+		})
+	}); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.Subscription{}, infra.ErrNotFound
 		}
-		return models.Subscription{}, fmt.Errorf("postgres GetByID(): %w", err)
+		return models.Subscription{}, fmt.Errorf("postgres GetByID(): %w", mapError(err))
 	}
 
 	return data, nil
@@ -108,15 +151,20 @@ func (db *PostgresDB) GetByID(ctx context.Context, id int) (models.Subscription,
 func (db *PostgresDB) Update(ctx context.Context, data models.Subscription) error {
 	const query = `
 		UPDATE subscriptions
-		SET service_name = $1, price = $2, user_id = $3, start_date = $4, end_date = $5
-		WHERE id = $6;
+		SET service_name = $1, price = $2, user_id = $3, start_date = $4, end_date = $5, team_id = $6, category = $7, trial_end = $8, updated_at = now()
+		WHERE id = $9;
 	`
 
-	ct, err := db.pool.Exec(ctx, query,
-		data.ServiceName, data.Price, data.UserID, data.StartDate, data.EndDate, data.ID,
-	)
+	// UPDATE выставляет абсолютные значения, поэтому повтор безопасен.
+	var ct pgconn.CommandTag
+	err := db.retry(ctx, "update", true, func() (err error) {
+		ct, err = db.pool.Exec(ctx, query,
+			data.ServiceName, data.Price, data.UserID, data.StartDate, data.EndDate, data.TeamID, data.Category, data.TrialEnd, data.ID,
+		)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("postgres Update(): %w", err)
+		return fmt.Errorf("postgres Update(): %w", mapError(err))
 	}
 	if ct.RowsAffected() == 0 {
 		return infra.ErrNotFound
@@ -130,9 +178,14 @@ func (db *PostgresDB) Delete(ctx context.Context, id int) error {
 		DELETE FROM subscriptions WHERE id = $1;
 	`
 
-	ct, err := db.pool.Exec(ctx, query, id)
+	// Повтор после успешного DELETE вернул бы ErrNotFound, поэтому повторяем только неприменённый запрос.
+	var ct pgconn.CommandTag
+	err := db.retry(ctx, "delete", false, func() (err error) {
+		ct, err = db.pool.Exec(ctx, query, id)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("postgres Delete(): %w", err)
+		return fmt.Errorf("postgres Delete(): %w", mapError(err))
 	}
 	if ct.RowsAffected() == 0 {
 		return infra.ErrNotFound
@@ -142,7 +195,7 @@ func (db *PostgresDB) Delete(ctx context.Context, id int) error {
 
 func (db *PostgresDB) List(ctx context.Context, filter infra.ListFilter) ([]models.Subscription, error) {
 	query := `
-		SELECT id, service_name, price, user_id, start_date, end_date
+		SELECT id, service_name, price, user_id, start_date, end_date, team_id, category, trial_end, created_at, updated_at
 		FROM subscriptions
 	`
 	var (
@@ -151,15 +204,127 @@ func (db *PostgresDB) List(ctx context.Context, filter infra.ListFilter) ([]mode
 		i     = 1
 	)
 
-	if filter.UserID != nil {
-		conds = append(conds, fmt.Sprintf("user_id = $%d", i))
-		args = append(args, *filter.UserID)
+	if len(filter.UserIDs) > 0 {
+		if filter.IncludeShared {
+			conds = append(conds, fmt.Sprintf(
+				"(user_id = ANY($%d) OR id IN (SELECT subscription_id FROM subscription_participants WHERE user_id = ANY($%d)))", i, i))
+		} else {
+			conds = append(conds, fmt.Sprintf("user_id = ANY($%d)", i))
+		}
+		args = append(args, filter.UserIDs)
+		i++
+	}
+
+	if len(filter.ServiceNames) > 0 {
+		conds = append(conds, fmt.Sprintf("service_name = ANY($%d)", i))
+		args = append(args, filter.ServiceNames)
+		i++
+	}
+
+	if len(filter.Categories) > 0 {
+		conds = append(conds, fmt.Sprintf("category = ANY($%d)", i))
+		args = append(args, filter.Categories)
+		i++
+	}
+
+	if len(filter.TeamIDs) > 0 {
+		conds = append(conds, fmt.Sprintf("team_id = ANY($%d)", i))
+		args = append(args, filter.TeamIDs)
+		i++
+	}
+
+	if len(filter.OrganizationIDs) > 0 {
+		conds = append(conds, fmt.Sprintf("team_id IN (SELECT id FROM teams WHERE organization_id = ANY($%d))", i))
+		args = append(args, filter.OrganizationIDs)
+		i++
+	}
+
+	if filter.VisibleTo != nil {
+		conds = append(conds, fmt.Sprintf(
+			"(user_id = $%d OR team_id = ANY($%d) OR id IN (SELECT subscription_id FROM subscription_participants WHERE user_id = $%d))", i, i+1, i))
+		args = append(args, filter.VisibleTo.UserID, filter.VisibleTo.TeamIDs)
+		i += 2
+	}
+
+	if filter.CreatedAfter != nil {
+		conds = append(conds, fmt.Sprintf("created_at > $%d", i))
+		args = append(args, *filter.CreatedAfter)
+		i++
+	}
+
+	if filter.UpdatedAfter != nil {
+		conds = append(conds, fmt.Sprintf("updated_at > $%d", i))
+		args = append(args, *filter.UpdatedAfter)
+		i++
+	}
+
+	if filter.PriceMin != nil {
+		conds = append(conds, fmt.Sprintf("price >= $%d", i))
+		args = append(args, *filter.PriceMin)
+		i++
+	}
+
+	if filter.PriceMax != nil {
+		conds = append(conds, fmt.Sprintf("price <= $%d", i))
+		args = append(args, *filter.PriceMax)
+		i++
+	}
+
+	if filter.StartFrom != nil {
+		conds = append(conds, fmt.Sprintf("start_date >= $%d", i))
+		args = append(args, *filter.StartFrom)
+		i++
+	}
+
+	if filter.StartTo != nil {
+		conds = append(conds, fmt.Sprintf("start_date <= $%d", i))
+		args = append(args, *filter.StartTo)
 		i++
 	}
 
-	if filter.ServiceName != nil {
-		conds = append(conds, fmt.Sprintf("service_name = $%d", i))
-		args = append(args, *filter.ServiceName)
+	if filter.TrialEndFrom != nil {
+		conds = append(conds, fmt.Sprintf("trial_end >= $%d", i))
+		args = append(args, *filter.TrialEndFrom)
+		i++
+	}
+
+	if filter.TrialEndTo != nil {
+		conds = append(conds, fmt.Sprintf("trial_end <= $%d", i))
+		args = append(args, *filter.TrialEndTo)
+		i++
+	}
+
+	if filter.ActiveAt != nil {
+		conds = append(conds, fmt.Sprintf("start_date <= $%d AND (end_date IS NULL OR end_date >= $%d) AND "+notPausedAt, i, i, i, i))
+		args = append(args, *filter.ActiveAt)
+		i++
+	}
+
+	var relevance string
+	if filter.Query != nil {
+		// $i - подстрока для ILIKE, $i+1 - префикс, $i+2 - сам запрос для точного совпадения и pg_trgm.
+		conds = append(conds, fmt.Sprintf("(service_name ILIKE $%d OR service_name %% $%d)", i, i+2))
+		relevance = fmt.Sprintf(
+			"(CASE WHEN lower(service_name) = lower($%d) THEN 2 ELSE 0 END"+
+				" + CASE WHEN service_name ILIKE $%d THEN 1 ELSE 0 END"+
+				" + similarity(service_name, $%d)) DESC", i+2, i+1, i+2)
+		pattern := escapeLike(*filter.Query)
+		args = append(args, "%"+pattern+"%", pattern+"%", *filter.Query)
+		i += 3
+	}
+
+	switch filter.Status {
+	case "active":
+		conds = append(conds, fmt.Sprintf("start_date <= $%d AND (end_date IS NULL OR end_date >= $%d) AND "+notPausedAt, i, i, i, i))
+		args = append(args, filter.StatusAt)
+		i++
+	case "ended":
+		conds = append(conds, fmt.Sprintf("end_date < $%d", i))
+		args = append(args, filter.StatusAt)
+		i++
+	case "future":
+		conds = append(conds, fmt.Sprintf("start_date > $%d", i))
+		args = append(args, filter.StatusAt)
 		i++
 	}
 
@@ -167,7 +332,11 @@ func (db *PostgresDB) List(ctx context.Context, filter infra.ListFilter) ([]mode
 		query += " WHERE " + strings.Join(conds, " AND ")
 	}
 
-	query += " ORDER BY id DESC"
+	order, err := orderBy(filter.Sort, relevance)
+	if err != nil {
+		return nil, fmt.Errorf("postgres List(): %w", err)
+	}
+	query += " ORDER BY " + order
 
 	if filter.Limit > 0 {
 		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
@@ -176,27 +345,343 @@ func (db *PostgresDB) List(ctx context.Context, filter infra.ListFilter) ([]mode
 		query += fmt.Sprintf(" OFFSET %d", filter.Offset)
 	}
 
-	rows, err := db.pool.Query(ctx, query, args...)
+	var data []models.Subscription
+	if err := db.retry(ctx, "list", true, func() error {
+		return db.read(ctx, func(pool *pgxpool.Pool) error {
+			rows, err := pool.Query(ctx, query, args...)
+			if err != nil {
+				return err
+			}
+			data, err = pgx.CollectRows(rows, scanSubscription)
+			if err != nil || len(data) == 0 {
+				return err
+			}
+			batch := &pgx.Batch{}
+			queueChildren(batch, data)
+			return pool.SendBatch(ctx, batch).Close()
+		})
+	}); err != nil {
+		return nil, fmt.Errorf("postgres List(): %w", mapError(err))
+	}
+
+	return data, nil
+}
+
+func (db *PostgresDB) SetParticipant(ctx context.Context, subscriptionID int, data models.Participant) error {
+	const (
+		upsert = `
+			INSERT INTO subscription_participants (subscription_id, user_id, share_weight, fixed_amount)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (subscription_id, user_id)
+			DO UPDATE SET share_weight = EXCLUDED.share_weight, fixed_amount = EXCLUDED.fixed_amount;
+		`
+		touch = `UPDATE subscriptions SET updated_at = now() WHERE id = $1;`
+	)
+
+	// Состав участников меняет доли, поэтому вместе с ним обновляется updated_at подписки.
+	// Upsert выставляет абсолютные значения, поэтому повтор безопасен.
+	if err := db.retry(ctx, "set_participant", true, func() error {
+		return pgx.BeginFunc(ctx, db.pool, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, upsert, subscriptionID, data.UserID, data.ShareWeight, data.FixedAmount); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, touch, subscriptionID)
+			return err
+		})
+	}); err != nil {
+		return fmt.Errorf("postgres SetParticipant(): %w", mapError(err))
+	}
+
+	return nil
+}
+
+func (db *PostgresDB) RemoveParticipant(ctx context.Context, subscriptionID int, userID string) error {
+	const (
+		remove = `DELETE FROM subscription_participants WHERE subscription_id = $1 AND user_id = $2;`
+		touch  = `UPDATE subscriptions SET updated_at = now() WHERE id = $1;`
+	)
+
+	var ct pgconn.CommandTag
+	err := db.retry(ctx, "remove_participant", false, func() error {
+		return pgx.BeginFunc(ctx, db.pool, func(tx pgx.Tx) (err error) {
+			if ct, err = tx.Exec(ctx, remove, subscriptionID, userID); err != nil || ct.RowsAffected() == 0 {
+				return err
+			}
+			_, err = tx.Exec(ctx, touch, subscriptionID)
+			return err
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("postgres List(): %w", err)
+		return fmt.Errorf("postgres RemoveParticipant(): %w", mapError(err))
+	}
+	if ct.RowsAffected() == 0 {
+		return infra.ErrNotFound
 	}
+	return nil
+}
 
-	var data []models.Subscription
-	for rows.Next() {
-		var dataItem models.Subscription
-		if err := rows.Scan(
-			&dataItem.ID, &dataItem.ServiceName, &dataItem.Price, &dataItem.UserID,
-			&dataItem.StartDate, &dataItem.EndDate,
-		); err != nil {
-			return nil, fmt.Errorf("postgres List(), rows.Scan(): %w", err)
+// getByIDBatch - пакет чтения подписки subs[0].ID вместе с её дочерними записями в subs[0].
+func getByIDBatch(subs []models.Subscription) *pgx.Batch {
+	const query = `
+		SELECT id, service_name, price, user_id, start_date, end_date, team_id, category, trial_end, created_at, updated_at
+		FROM subscriptions
+		WHERE id = $1;
+	`
+
+	batch := &pgx.Batch{}
+	batch.Queue(query, subs[0].ID).QueryRow(func(row pgx.Row) error {
+		return row.Scan(
+			&subs[0].ID, &subs[0].ServiceName, &subs[0].Price, &subs[0].UserID, &subs[0].StartDate, &subs[0].EndDate, &subs[0].TeamID, &subs[0].Category, &subs[0].TrialEnd, &subs[0].CreatedAt, &subs[0].UpdatedAt,
+		)
+	})
+	queueChildren(batch, subs)
+	return batch
+}
+
+// queueChildren ставит в пакет загрузку участников, изменений цены и пауз подписок.
+func queueChildren(batch *pgx.Batch, subs []models.Subscription) {
+	ids := make([]int, 0, len(subs))
+	byID := make(map[int]int, len(subs))
+	for i, sub := range subs {
+		ids = append(ids, sub.ID)
+		byID[sub.ID] = i
+	}
+
+	loadChildren(batch, `
+		SELECT subscription_id, user_id, share_weight, fixed_amount
+		FROM subscription_participants
+		WHERE subscription_id = ANY($1)
+		ORDER BY subscription_id, user_id;
+	`, ids, func(p *models.Participant) []any {
+		return []any{&p.UserID, &p.ShareWeight, &p.FixedAmount}
+	}, func(id int, p models.Participant) {
+		subs[byID[id]].Participants = append(subs[byID[id]].Participants, p)
+	})
+	loadChildren(batch, `
+		SELECT subscription_id, effective_from, price
+		FROM subscription_price_changes
+		WHERE subscription_id = ANY($1)
+		ORDER BY subscription_id, effective_from;
+	`, ids, func(c *models.PriceChange) []any {
+		return []any{&c.EffectiveFrom, &c.Price}
+	}, func(id int, c models.PriceChange) {
+		subs[byID[id]].PriceChanges = append(subs[byID[id]].PriceChanges, c)
+	})
+	loadChildren(batch, `
+		SELECT subscription_id, start_month, end_month
+		FROM subscription_pauses
+		WHERE subscription_id = ANY($1)
+		ORDER BY subscription_id, start_month;
+	`, ids, func(p *models.Pause) []any {
+		return []any{&p.Start, &p.End}
+	}, func(id int, p models.Pause) {
+		subs[byID[id]].Pauses = append(subs[byID[id]].Pauses, p)
+	})
+}
+
+// loadChildren ставит в пакет запрос дочерних записей подписок ids.
+// Первая колонка запроса - subscription_id, остальные сканируются в поля T из fields.
+func loadChildren[T any](batch *pgx.Batch, query string, ids []int, fields func(*T) []any, add func(subscriptionID int, item T)) {
+	batch.Queue(query, ids).Query(func(rows pgx.Rows) error {
+		var (
+			subscriptionID int
+			item           T
+		)
+		_, err := pgx.ForEachRow(rows, append([]any{&subscriptionID}, fields(&item)...), func() error {
+			add(subscriptionID, item)
+			var zero T
+			item = zero
+			return nil
+		})
+		return err
+	})
+}
+
+func scanSubscription(row pgx.CollectableRow) (models.Subscription, error) {
+	var data models.Subscription
+	err := row.Scan(
+		&data.ID, &data.ServiceName, &data.Price, &data.UserID,
+		&data.StartDate, &data.EndDate, &data.TeamID, &data.Category, &data.TrialEnd, &data.CreatedAt, &data.UpdatedAt,
+	)
+	return data, err
+}
+
+// countActive - количество подписок, действующих в текущем месяце (без приостановленных).
+func (db *PostgresDB) countActive(ctx context.Context) (int64, error) {
+	const query = `
+		SELECT count(*)
+		FROM subscriptions
+		WHERE start_date <= date_trunc('month', now())
+		  AND (end_date IS NULL OR end_date >= date_trunc('month', now()))
+		  AND NOT EXISTS (
+			SELECT 1 FROM subscription_pauses p
+			WHERE p.subscription_id = subscriptions.id
+			  AND p.start_month <= date_trunc('month', now())
+			  AND (p.end_month IS NULL OR p.end_month >= date_trunc('month', now()))
+		  );
+	`
+	var count int64
+
+	if err := db.retry(ctx, "count_active", true, func() error {
+		return db.read(ctx, func(pool *pgxpool.Pool) error {
+			return pool.QueryRow(ctx, query).Scan(&count)
+		})
+	}); err != nil {
+		return 0, fmt.Errorf("postgres countActive(): %w", err)
+	}
+
+	return count, nil
+}
+
+// notPausedAt - подписка не приостановлена в месяце из параметров с номерами %d (дважды).
+const notPausedAt = `NOT EXISTS (SELECT 1 FROM subscription_pauses p WHERE p.subscription_id = subscriptions.id` +
+	` AND p.start_month <= $%d AND (p.end_month IS NULL OR p.end_month >= $%d))`
+
+// sortColumns - колонки, по которым разрешена сортировка. Имена колонок в SQL
+// берутся только отсюда, пользовательский ввод в запрос не попадает.
+var sortColumns = map[string]string{
+	"price":        "price",
+	"start_date":   "start_date",
+	"end_date":     "end_date",
+	"service_name": "service_name",
+	"created_at":   "created_at",
+}
+
+// orderBy строит ORDER BY по ключам сортировки; без ключей - по relevance (если задана), затем по id.
+func orderBy(keys []infra.SortKey, relevance string) (string, error) {
+	parts := make([]string, 0, len(keys)+2)
+	if len(keys) == 0 && relevance != "" {
+		parts = append(parts, relevance)
+	}
+	for _, key := range keys {
+		column, ok := sortColumns[key.Column]
+		if !ok {
+			return "", fmt.Errorf("недопустимая колонка сортировки %q", key.Column)
+		}
+		if key.Desc {
+			column += " DESC"
 		}
-		data = append(data, dataItem)
+		parts = append(parts, column)
 	}
+	return strings.Join(append(parts, "id DESC"), ", "), nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
 
-	rows.Close()
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("postgres List(), rows.Err(): %w", err)
+// escapeLike экранирует спецсимволы LIKE, чтобы запрос искался буквально.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// SupportsTextSearch - поиск по ListFilter.Query выполняется в БД (ILIKE и pg_trgm).
+func (db *PostgresDB) SupportsTextSearch() bool {
+	return true
+}
+
+func (db *PostgresDB) SetPriceChange(ctx context.Context, subscriptionID int, data models.PriceChange) error {
+	const (
+		upsert = `
+			INSERT INTO subscription_price_changes (subscription_id, effective_from, price)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (subscription_id, effective_from)
+			DO UPDATE SET price = EXCLUDED.price;
+		`
+		touch = `UPDATE subscriptions SET updated_at = now() WHERE id = $1;`
+	)
+
+	if err := db.retry(ctx, "set_price_change", true, func() error {
+		return pgx.BeginFunc(ctx, db.pool, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, upsert, subscriptionID, data.EffectiveFrom, data.Price); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, touch, subscriptionID)
+			return err
+		})
+	}); err != nil {
+		return fmt.Errorf("postgres SetPriceChange(): %w", mapError(err))
 	}
 
-	return data, nil
+	return nil
+}
+
+func (db *PostgresDB) RemovePriceChange(ctx context.Context, subscriptionID int, effectiveFrom time.Time) error {
+	const (
+		remove = `DELETE FROM subscription_price_changes WHERE subscription_id = $1 AND effective_from = $2;`
+		touch  = `UPDATE subscriptions SET updated_at = now() WHERE id = $1;`
+	)
+
+	var ct pgconn.CommandTag
+	err := db.retry(ctx, "remove_price_change", false, func() error {
+		return pgx.BeginFunc(ctx, db.pool, func(tx pgx.Tx) (err error) {
+			if ct, err = tx.Exec(ctx, remove, subscriptionID, effectiveFrom); err != nil || ct.RowsAffected() == 0 {
+				return err
+			}
+			_, err = tx.Exec(ctx, touch, subscriptionID)
+			return err
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("postgres RemovePriceChange(): %w", mapError(err))
+	}
+	if ct.RowsAffected() == 0 {
+		return infra.ErrNotFound
+	}
+	return nil
+}
+
+func (db *PostgresDB) AddPause(ctx context.Context, subscriptionID int, data models.Pause) error {
+	const (
+		insert = `INSERT INTO subscription_pauses (subscription_id, start_month, end_month) VALUES ($1, $2, $3);`
+		touch  = `UPDATE subscriptions SET updated_at = now() WHERE id = $1;`
+	)
+
+	// INSERT повторяется, только если он гарантированно не был применён.
+	if err := db.retry(ctx, "add_pause", false, func() error {
+		return pgx.BeginFunc(ctx, db.pool, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, insert, subscriptionID, data.Start, data.End); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, touch, subscriptionID)
+			return err
+		})
+	}); err != nil {
+		return fmt.Errorf("postgres AddPause(): %w", mapError(err))
+	}
+
+	return nil
+}
+
+func (db *PostgresDB) ClosePause(ctx context.Context, subscriptionID int, lastPaused time.Time) error {
+	const (
+		closePause = `
+			UPDATE subscription_pauses SET end_month = $2
+			WHERE subscription_id = $1 AND end_month IS NULL AND start_month <= $2;
+		`
+		cancelPause = `DELETE FROM subscription_pauses WHERE subscription_id = $1 AND end_month IS NULL;`
+		touch       = `UPDATE subscriptions SET updated_at = now() WHERE id = $1;`
+	)
+
+	// Пауза, которая ещё не началась к месяцу возобновления, удаляется целиком.
+	var ct pgconn.CommandTag
+	err := db.retry(ctx, "close_pause", false, func() error {
+		return pgx.BeginFunc(ctx, db.pool, func(tx pgx.Tx) (err error) {
+			if ct, err = tx.Exec(ctx, closePause, subscriptionID, lastPaused); err != nil {
+				return err
+			}
+			if ct.RowsAffected() == 0 {
+				if ct, err = tx.Exec(ctx, cancelPause, subscriptionID); err != nil || ct.RowsAffected() == 0 {
+					return err
+				}
+			}
+			_, err = tx.Exec(ctx, touch, subscriptionID)
+			return err
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("postgres ClosePause(): %w", mapError(err))
+	}
+	if ct.RowsAffected() == 0 {
+		return infra.ErrNotFound
+	}
+	return nil
 }
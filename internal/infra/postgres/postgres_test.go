@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+)
+
+func TestOrderBy(t *testing.T) {
+	order, err := orderBy(nil, "")
+	require.NoError(t, err)
+	require.Equal(t, "id DESC", order)
+
+	order, err = orderBy([]infra.SortKey{{Column: "price", Desc: true}, {Column: "service_name"}}, "rel DESC")
+	require.NoError(t, err)
+	require.Equal(t, "price DESC, service_name, id DESC", order)
+
+	_, err = orderBy([]infra.SortKey{{Column: "price; DROP TABLE subscriptions"}}, "")
+	require.Error(t, err)
+}
+
+func TestOrderBy_Relevance(t *testing.T) {
+	order, err := orderBy(nil, "rel DESC")
+	require.NoError(t, err)
+	require.Equal(t, "rel DESC, id DESC", order)
+}
+
+func TestEscapeLike(t *testing.T) {
+	require.Equal(t, `100\%\_a\\b`, escapeLike(`100%_a\b`))
+}
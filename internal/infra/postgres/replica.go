@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+)
+
+// replica - пул реплики для чтения и её состояние по результатам периодического Ping.
+type replica struct {
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func (r *replica) close() {
+	close(r.stop)
+	<-r.done
+	r.pool.Close()
+}
+
+// startReplica проверяет реплику сразу и затем каждые period в фоне.
+// Недоступная при старте реплика не мешает запуску: чтения идут на primary, пока она не поднимется.
+func (db *PostgresDB) startReplica(pool *pgxpool.Pool, period, timeout time.Duration) {
+	r := &replica{pool: pool, stop: make(chan struct{}), done: make(chan struct{})}
+	db.replica = r
+	db.checkReplica(timeout)
+
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				db.checkReplica(timeout)
+			}
+		}
+	}()
+}
+
+func (db *PostgresDB) checkReplica(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := db.replica.pool.Ping(ctx)
+	db.setReplicaHealthy(err == nil, err)
+}
+
+func (db *PostgresDB) setReplicaHealthy(healthy bool, err error) {
+	if db.replica.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		db.logger.Info("Postgres реплика снова доступна, чтения переключены на неё",
+			zap.String("component", "infra.Database(PostgresDB)"),
+		)
+		return
+	}
+	db.logger.Warn("Postgres реплика недоступна, чтения переключены на primary",
+		zap.String("component", "infra.Database(PostgresDB)"),
+		zap.Error(err),
+	)
+}
+
+// reader - пул для чтения: реплика, если она настроена, доступна и запрос не требует primary.
+func (db *PostgresDB) reader(ctx context.Context) *pgxpool.Pool {
+	if db.replica == nil || !db.replica.healthy.Load() || infra.PrimaryReadsFromContext(ctx) {
+		return db.pool
+	}
+	return db.replica.pool
+}
+
+// read выполняет чтение на пуле из reader. Если реплика отвечает временной ошибкой,
+// она помечается недоступной до следующей успешной проверки, а чтение повторяется на primary.
+func (db *PostgresDB) read(ctx context.Context, fn func(pool *pgxpool.Pool) error) error {
+	pool := db.reader(ctx)
+	err := fn(pool)
+	if err == nil || pool == db.pool || !retryable(err, true) || ctx.Err() != nil {
+		return err
+	}
+
+	db.setReplicaHealthy(false, err)
+	return fn(db.pool)
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+)
+
+// newLazyPool создаёт пул без подключения: pgxpool соединяется при первом запросе.
+func newLazyPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	pool, err := pgxpool.New(context.Background(), "postgres://u:p@127.0.0.1:1/db")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func newReplicaDB(t *testing.T) *PostgresDB {
+	db := &PostgresDB{pool: newLazyPool(t), logger: zap.NewNop()}
+	db.replica = &replica{pool: newLazyPool(t)}
+	db.replica.healthy.Store(true)
+	return db
+}
+
+func TestReader(t *testing.T) {
+	db := newReplicaDB(t)
+	ctx := context.Background()
+
+	require.Same(t, db.replica.pool, db.reader(ctx))
+	require.Same(t, db.pool, db.reader(infra.WithPrimaryReads(ctx)))
+
+	db.replica.healthy.Store(false)
+	require.Same(t, db.pool, db.reader(ctx))
+
+	require.Same(t, db.pool, (&PostgresDB{pool: db.pool}).reader(ctx))
+}
+
+func TestRead_FallsBackToPrimary(t *testing.T) {
+	db := newReplicaDB(t)
+
+	var used []*pgxpool.Pool
+	err := db.read(context.Background(), func(pool *pgxpool.Pool) error {
+		used = append(used, pool)
+		if pool == db.replica.pool {
+			return io.ErrUnexpectedEOF
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []*pgxpool.Pool{db.replica.pool, db.pool}, used)
+	require.False(t, db.replica.healthy.Load())
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/config"
+	"github.com/sunr3d/subscription-aggregator/internal/metrics"
+)
+
+// retryPolicy - ограниченный экспоненциальный backoff с джиттером для временных ошибок БД.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+func newRetryPolicy(cfg config.PostgresConfig) retryPolicy {
+	p := retryPolicy{
+		maxAttempts: cfg.RetryMaxAttempts,
+		baseDelay:   cfg.RetryBaseDelay,
+		maxDelay:    cfg.RetryMaxDelay,
+	}
+	if p.maxAttempts < 1 {
+		p.maxAttempts = 1
+	}
+	if p.maxDelay < p.baseDelay {
+		p.maxDelay = p.baseDelay
+	}
+	return p
+}
+
+// delay - задержка перед попыткой attempt+1: base*2^(attempt-1), не больше maxDelay,
+// со случайным джиттером в пределах [d/2, d].
+func (p retryPolicy) delay(attempt int) time.Duration {
+	d := p.maxDelay
+	if shift := attempt - 1; shift < 32 {
+		if exp := p.baseDelay << shift; exp > 0 && exp < d {
+			d = exp
+		}
+	}
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(d-half+1)
+}
+
+// retry выполняет fn, повторяя её при временных ошибках.
+// Для неидемпотентных операций (idempotent == false) повтор допустим, только если
+// запрос гарантированно не был применён: он не дошёл до сервера или транзакция откатилась.
+// Повтор не начинается, если до дедлайна контекста не хватает времени на паузу.
+func (db *PostgresDB) retry(ctx context.Context, op string, idempotent bool, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= db.retryPolicy.maxAttempts || !retryable(err, idempotent) {
+			return err
+		}
+
+		wait := db.retryPolicy.delay(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return err
+		}
+
+		db.logger.Warn("временная ошибка БД, повтор запроса",
+			zap.String("operation", op),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", wait),
+			zap.Error(err),
+		)
+		metrics.DBRetries.WithLabelValues(op).Inc()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// SQLSTATE коды, при которых запрос гарантированно не был применён.
+var notAppliedCodes = map[string]bool{
+	codeSerializationFailure: true,
+	"40P01":                  true, // deadlock_detected
+	"57P03":                  true, // cannot_connect_now
+}
+
+// SQLSTATE коды обрыва соединения: запрос мог успеть выполниться.
+var connectionLostCodes = map[string]bool{
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+}
+
+func retryable(err error, idempotent bool) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if notAppliedCodes[pgErr.Code] {
+			return true
+		}
+		// Класс 08 - connection exception.
+		return idempotent && (connectionLostCodes[pgErr.Code] || strings.HasPrefix(pgErr.Code, "08"))
+	}
+
+	if !idempotent {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newRetryDB(attempts int) *PostgresDB {
+	return &PostgresDB{
+		logger:      zap.NewNop(),
+		retryPolicy: retryPolicy{maxAttempts: attempts, baseDelay: time.Millisecond, maxDelay: 2 * time.Millisecond},
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	serialization := &pgconn.PgError{Code: codeSerializationFailure}
+	adminShutdown := &pgconn.PgError{Code: "57P01"}
+	connFailure := &pgconn.PgError{Code: "08006"}
+	checkViolation := &pgconn.PgError{Code: codeCheckViolation}
+
+	cases := []struct {
+		name       string
+		err        error
+		idempotent bool
+		want       bool
+	}{
+		{"serialization write", serialization, false, true},
+		{"admin shutdown read", adminShutdown, true, true},
+		{"admin shutdown write", adminShutdown, false, false},
+		{"connection failure read", connFailure, true, true},
+		{"eof read", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), true, true},
+		{"eof write", io.ErrUnexpectedEOF, false, false},
+		{"check violation", checkViolation, true, false},
+		{"context canceled", context.Canceled, true, false},
+		{"plain", errors.New("boom"), true, false},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, retryable(tc.err, tc.idempotent), tc.name)
+	}
+}
+
+func TestRetry_SucceedsAfterTransientError(t *testing.T) {
+	db := newRetryDB(3)
+
+	calls := 0
+	err := db.retry(context.Background(), "get_by_id", true, func() error {
+		calls++
+		if calls < 3 {
+			return &pgconn.PgError{Code: codeSerializationFailure}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestRetry_StopsAtMaxAttempts(t *testing.T) {
+	db := newRetryDB(2)
+
+	calls := 0
+	err := db.retry(context.Background(), "get_by_id", true, func() error {
+		calls++
+		return io.ErrUnexpectedEOF
+	})
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Equal(t, 2, calls)
+}
+
+func TestRetry_RespectsDeadline(t *testing.T) {
+	db := newRetryDB(5)
+	db.retryPolicy.baseDelay, db.retryPolicy.maxDelay = time.Second, time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	err := db.retry(ctx, "list", true, func() error {
+		calls++
+		return io.ErrUnexpectedEOF
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, calls)
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := retryPolicy{maxAttempts: 5, baseDelay: 100 * time.Millisecond, maxDelay: 300 * time.Millisecond}
+
+	for attempt, limit := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 10: 300 * time.Millisecond} {
+		d := p.delay(attempt)
+		require.GreaterOrEqual(t, d, limit/2)
+		require.LessOrEqual(t, d, limit)
+	}
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sunr3d/subscription-aggregator/internal/tracing"
+)
+
+var (
+	_ pgx.QueryTracer = (*queryTracer)(nil)
+	_ pgx.BatchTracer = (*queryTracer)(nil)
+)
+
+// queryTracer открывает спан на каждый запрос pgx, в том числе на каждый запрос пакета.
+type queryTracer struct{}
+
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = startQuerySpan(ctx, data.SQL)
+	return ctx
+}
+
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	endSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func (queryTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, _ = tracing.Tracer().Start(ctx, "postgres BATCH",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName("BATCH"),
+			attribute.Int("db.operation.batch.size", data.Batch.Len()),
+		),
+	)
+	return ctx
+}
+
+// TraceBatchQuery вызывается уже после выполнения запроса пакета, поэтому его спан
+// отмечает результат запроса внутри спана пакета, а длительность - у спана пакета.
+func (queryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	_, span := startQuerySpan(ctx, data.SQL)
+	endSpan(span, data.Err)
+}
+
+func (queryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	endSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func startQuerySpan(ctx context.Context, sql string) (context.Context, trace.Span) {
+	operation := sqlOperation(sql)
+	return tracing.Tracer().Start(ctx, "postgres "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(strings.Join(strings.Fields(sql), " ")),
+		),
+	)
+}
+
+func endSpan(span trace.Span, err error) {
+	// Отсутствие строк - штатный исход для GetByID, а не ошибка запроса.
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func sqlOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func TestQueryTracer_Spans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	tracer := queryTracer{}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL: "\n\t\tSELECT id\n\t\tFROM subscriptions\n\t\tWHERE id = $1;\n\t",
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: pgx.ErrNoRows})
+
+	ctx = tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "DELETE FROM subscriptions WHERE id = $1;"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("ошибка БД")})
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "postgres SELECT", spans[0].Name())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	for _, attr := range spans[0].Attributes() {
+		if attr.Key == "db.query.text" {
+			require.Equal(t, "SELECT id FROM subscriptions WHERE id = $1;", attr.Value.AsString())
+		}
+	}
+
+	require.Equal(t, "postgres DELETE", spans[1].Name())
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestQueryTracer_BatchSpans(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	tracer := queryTracer{}
+	batch := getByIDBatch([]models.Subscription{{ID: 1}})
+
+	// Так pgx вызывает BatchTracer при pool.SendBatch в GetByID: подписка не найдена.
+	ctx := tracer.TraceBatchStart(context.Background(), nil, pgx.TraceBatchStartData{Batch: batch})
+	for _, qq := range batch.QueuedQueries {
+		tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{SQL: qq.SQL, Args: qq.Arguments})
+	}
+	tracer.TraceBatchEnd(ctx, nil, pgx.TraceBatchEndData{Err: pgx.ErrNoRows})
+
+	spans := sr.Ended()
+	require.Len(t, spans, 5)
+
+	parent := spans[len(spans)-1]
+	require.Equal(t, "postgres BATCH", parent.Name())
+	require.Equal(t, codes.Unset, parent.Status().Code)
+
+	tables := make([]string, 0, 4)
+	for _, span := range spans[:4] {
+		require.Equal(t, "postgres SELECT", span.Name())
+		require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		for _, attr := range span.Attributes() {
+			if attr.Key == "db.query.text" {
+				_, from, _ := strings.Cut(attr.Value.AsString(), " FROM ")
+				table, _, _ := strings.Cut(from, " ")
+				tables = append(tables, table)
+			}
+		}
+	}
+	require.Equal(t, []string{"subscriptions", "subscription_participants", "subscription_price_changes", "subscription_pauses"}, tables)
+}
+
+func TestQueryTracer_BatchError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	tracer := queryTracer{}
+	batch := &pgx.Batch{}
+	batch.Queue("SELECT 1;")
+
+	ctx := tracer.TraceBatchStart(context.Background(), nil, pgx.TraceBatchStartData{Batch: batch})
+	tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{SQL: "SELECT 1;", Err: errors.New("ошибка БД")})
+	tracer.TraceBatchEnd(ctx, nil, pgx.TraceBatchEndData{Err: errors.New("ошибка БД")})
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
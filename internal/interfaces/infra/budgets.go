@@ -0,0 +1,22 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Budgets --output=../../../mocks --filename=mock_budgets.go --with-expecter
+type Budgets interface {
+	CreateBudget(ctx context.Context, data models.Budget) (int, error)
+	GetBudget(ctx context.Context, id int) (models.Budget, error)
+	// ListBudgets - бюджеты по возрастанию id, начиная с afterID, не больше limit.
+	ListBudgets(ctx context.Context, afterID, limit int) ([]models.Budget, error)
+
+	// ClaimAlert отмечает порог бюджета за период как отправленный; false - уже был отмечен.
+	// Отметка общая для всех экземпляров сервиса, поэтому уведомление отправляется один раз.
+	ClaimAlert(ctx context.Context, budgetID int, periodStart time.Time, threshold int) (bool, error)
+	// ReleaseAlert снимает отметку, если уведомление отправить не удалось.
+	ReleaseAlert(ctx context.Context, budgetID int, periodStart time.Time, threshold int) error
+}
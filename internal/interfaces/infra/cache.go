@@ -0,0 +1,16 @@
+package infra
+
+import (
+	"context"
+	"time"
+)
+
+// Cache - хранилище ключ-значение с TTL: in-process LRU или внешний кеш (Redis, Memcached).
+// Ошибки кеша не должны ломать запрос: вызывающий код считает их промахом.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Cache --output=../../../mocks --filename=mock_cache.go --with-expecter
+type Cache interface {
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
@@ -2,15 +2,73 @@ package infra
 
 import (
 	"context"
+	"time"
 
 	"github.com/sunr3d/subscription-aggregator/models"
 )
 
 type ListFilter struct {
-	UserID      *string
-	ServiceName *string
-	Limit       int
-	Offset      int
+	UserIDs []string
+	// IncludeShared - UserIDs совпадают не только с владельцем, но и с участниками подписки.
+	IncludeShared   bool
+	ServiceNames    []string
+	Categories      []string
+	TeamIDs         []int
+	OrganizationIDs []int
+	// VisibleTo - только подписки пользователя и его команд (при включённой авторизации).
+	VisibleTo    *Visibility
+	CreatedAfter *time.Time
+	UpdatedAfter *time.Time
+	PriceMin     *int
+	PriceMax     *int
+	StartFrom    *time.Time
+	StartTo      *time.Time
+	ActiveAt     *time.Time
+	// TrialEndFrom/TrialEndTo - последний бесплатный месяц в диапазоне, включительно.
+	TrialEndFrom *time.Time
+	TrialEndTo   *time.Time
+	// Query - поиск по service_name: подстрока без учёта регистра или триграммная схожесть.
+	// Без явной сортировки результат упорядочен по релевантности.
+	Query *string
+	// Status - active, ended или future относительно месяца StatusAt; пустой - любой.
+	// active и ActiveAt не включают подписки, приостановленные в этом месяце.
+	Status   string
+	StatusAt time.Time
+	Sort     []SortKey
+	Limit    int
+	Offset   int
+}
+
+// Visibility - подписки, доступные пользователю: его собственные, подписки его команд
+// и подписки, в которых он участник.
+type Visibility struct {
+	UserID  string
+	TeamIDs []int
+}
+
+// SortKey - колонка сортировки и направление; порядок по id добавляется всегда последним.
+type SortKey struct {
+	Column string
+	Desc   bool
+}
+
+type primaryReadsKey struct{}
+
+// WithPrimaryReads требует читать с primary в рамках запроса (read-your-writes),
+// даже если настроена реплика.
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+func PrimaryReadsFromContext(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryReadsKey{}).(bool)
+	return v
+}
+
+// TextSearcher реализуют хранилища, которые сами выполняют поиск по ListFilter.Query.
+// Для остальных сервис ищет в памяти.
+type TextSearcher interface {
+	SupportsTextSearch() bool
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Database --output=../../../mocks --filename=mock_database.go --with-expecter
@@ -20,4 +78,17 @@ type Database interface {
 	Update(ctx context.Context, data models.Subscription) error                 // Update (U)
 	Delete(ctx context.Context, id int) error                                   // Delete (D)
 	List(ctx context.Context, filter ListFilter) ([]models.Subscription, error) // List (L)
+
+	// Участники подписки; GetByID и List возвращают подписки вместе с участниками.
+	SetParticipant(ctx context.Context, subscriptionID int, data models.Participant) error
+	RemoveParticipant(ctx context.Context, subscriptionID int, userID string) error
+
+	// Запланированные изменения цены; GetByID и List возвращают подписки вместе с ними.
+	SetPriceChange(ctx context.Context, subscriptionID int, data models.PriceChange) error
+	RemovePriceChange(ctx context.Context, subscriptionID int, effectiveFrom time.Time) error
+
+	// Паузы; GetByID и List возвращают подписки вместе с ними. ClosePause завершает бессрочную паузу
+	// месяцем lastPaused (или удаляет её, если она начинается позже) и возвращает ErrNotFound, если такой паузы нет.
+	AddPause(ctx context.Context, subscriptionID int, data models.Pause) error
+	ClosePause(ctx context.Context, subscriptionID int, lastPaused time.Time) error
 }
@@ -1,7 +1,36 @@
 package infra
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrNotFound = errors.New("запись не найдена")
+
+	ErrInvalidInput    = errors.New("некорректное значение для типа колонки")
+	ErrCheckViolation  = errors.New("нарушено ограничение CHECK")
+	ErrUniqueViolation = errors.New("нарушено ограничение уникальности")
+	ErrForeignKey      = errors.New("нарушено ограничение внешнего ключа")
+	ErrSerialization   = errors.New("конфликт сериализации транзакций")
 )
+
+// ConstraintError - ошибка хранилища, вызванная данными запроса.
+// Err - одна из ErrInvalidInput/ErrCheckViolation/ErrUniqueViolation/ErrForeignKey/ErrSerialization,
+// Constraint - имя нарушенного ограничения, если БД его сообщила.
+type ConstraintError struct {
+	Err        error
+	Constraint string
+	Detail     string
+}
+
+func (e *ConstraintError) Error() string {
+	if e.Constraint != "" {
+		return fmt.Sprintf("%s (%s): %s", e.Err, e.Constraint, e.Detail)
+	}
+	return fmt.Sprintf("%s: %s", e.Err, e.Detail)
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,10 @@
+package infra
+
+import "context"
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=HealthChecker --output=../../../mocks --filename=mock_health_checker.go --with-expecter
+type HealthChecker interface {
+	Ping(ctx context.Context) error
+	// CheckMigrations возвращает ошибку, если схема БД не содержит нужных таблиц.
+	CheckMigrations(ctx context.Context) error
+}
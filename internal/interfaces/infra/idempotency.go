@@ -0,0 +1,21 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=IdempotencyStore --output=../../../mocks --filename=mock_idempotency_store.go --with-expecter
+type IdempotencyStore interface {
+	// Reserve резервирует ключ за запросом. Если ключ уже занят и не истёк,
+	// возвращает существующую запись и reserved == false.
+	Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (rec models.IdempotencyRecord, reserved bool, err error)
+	// Complete сохраняет ответ для зарезервированного ключа.
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	// Release снимает резерв, чтобы повтор запроса мог выполниться заново.
+	Release(ctx context.Context, key string) error
+	// PurgeExpired удаляет истёкшие ключи и возвращает их количество.
+	PurgeExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,14 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+// Notifier доставляет уведомления о бюджетах: в лог, webhook и т.п.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Notifier --output=../../../mocks --filename=mock_notifier.go --with-expecter
+type Notifier interface {
+	NotifyBudget(ctx context.Context, alert models.BudgetAlert) error
+}
@@ -0,0 +1,24 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=Organizations --output=../../../mocks --filename=mock_organizations.go --with-expecter
+type Organizations interface {
+	CreateOrganization(ctx context.Context, data models.Organization) (int, error)
+	GetOrganization(ctx context.Context, id int) (models.Organization, error)
+
+	CreateTeam(ctx context.Context, data models.Team) (int, error)
+	GetTeam(ctx context.Context, id int) (models.Team, error)
+	ListTeams(ctx context.Context, organizationID int) ([]models.Team, error)
+
+	// SetMember добавляет участника в команду или меняет его роль.
+	SetMember(ctx context.Context, data models.TeamMember) error
+	RemoveMember(ctx context.Context, teamID int, userID string) error
+	ListMembers(ctx context.Context, teamID int) ([]models.TeamMember, error)
+	// UserTeams - членство пользователя во всех командах.
+	UserTeams(ctx context.Context, userID string) ([]models.TeamMember, error)
+}
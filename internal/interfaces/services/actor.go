@@ -0,0 +1,16 @@
+package services
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor задаёт пользователя, от имени которого выполняется запрос (при включённой авторизации).
+func WithActor(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// ActorFromContext возвращает пользователя запроса. Без пользователя права не проверяются.
+func ActorFromContext(ctx context.Context) (string, bool) {
+	userID, ok := ctx.Value(actorKey{}).(string)
+	return userID, ok
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+// BudgetThresholds - пороги расходов в процентах бюджета, при достижении которых отправляется уведомление.
+var BudgetThresholds = []int{80, 100}
+
+// BudgetStatus - расходы по бюджету за текущий период [PeriodStart, PeriodEnd] (месяц или год).
+// Spent - по текущий месяц включительно, Projected - за весь период при текущих подписках,
+// Remaining - Amount - Spent (отрицательный, если бюджет превышен).
+type BudgetStatus struct {
+	Budget      models.Budget
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Spent       int
+	Remaining   int
+	Projected   int
+	// PercentUsed - Spent в процентах от Amount, округлённый вниз.
+	PercentUsed int
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=BudgetService --output=../../../mocks --filename=mock_budget_service.go --with-expecter
+type BudgetService interface {
+	CreateBudget(ctx context.Context, data models.Budget) (int, error)
+	GetBudget(ctx context.Context, id int) (models.Budget, error)
+	Status(ctx context.Context, id int) (BudgetStatus, error)
+	// EvaluateAlerts проверяет все бюджеты и отправляет уведомления о впервые достигнутых за период порогах.
+	EvaluateAlerts(ctx context.Context) error
+}
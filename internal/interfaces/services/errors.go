@@ -1,8 +1,61 @@
 package services
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+)
 
 var (
 	ErrValidation = errors.New("ошибка валидации")
 	ErrNotFound   = errors.New("запись не найдена")
+	ErrConflict   = errors.New("конфликт с существующими записями")
+	ErrForbidden  = errors.New("доступ запрещён")
 )
+
+// Коды ошибок полей.
+const (
+	CodeRequired      = "required"
+	CodeEmpty         = "empty"
+	CodeInvalidFormat = "invalid_format"
+	CodeInvalidValue  = "invalid_value"
+	CodeOutOfRange    = "out_of_range"
+	CodeNegative      = "negative"
+	CodeBeforeStart   = "before_start"
+	CodeNoFields      = "no_fields"
+)
+
+// FieldError - ошибка валидации конкретного поля.
+// Текст сообщения берётся из каталога i18n по Key с аргументами Args.
+type FieldError struct {
+	Field string
+	Code  string
+	Key   string
+	Args  []any
+}
+
+func (f FieldError) Message(lang i18n.Lang) string {
+	return i18n.T(lang, f.Key, f.Args...)
+}
+
+// ValidationError - ошибки валидации по полям, errors.Is(err, ErrValidation) == true.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func NewValidationError(fields ...FieldError) *ValidationError {
+	return &ValidationError{Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Message(i18n.Default))
+	}
+	return ErrValidation.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=OrganizationService --output=../../../mocks --filename=mock_organization_service.go --with-expecter
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, data models.Organization) (int, error)
+	GetOrganization(ctx context.Context, id int) (models.Organization, error)
+
+	CreateTeam(ctx context.Context, data models.Team) (int, error)
+	GetTeam(ctx context.Context, id int) (models.Team, error)
+	ListTeams(ctx context.Context, organizationID int) ([]models.Team, error)
+
+	SetMember(ctx context.Context, data models.TeamMember) error
+	RemoveMember(ctx context.Context, teamID int, userID string) error
+	ListMembers(ctx context.Context, teamID int) ([]models.TeamMember, error)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+// OverlapMode - реакция на пересечение подписки с уже существующими
+// подписками того же пользователя на тот же сервис.
+type OverlapMode string
+
+const (
+	OverlapWarn   OverlapMode = "warn"
+	OverlapReject OverlapMode = "reject"
+)
+
+func ParseOverlapMode(s string) (OverlapMode, bool) {
+	switch mode := OverlapMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case OverlapWarn, OverlapReject:
+		return mode, true
+	default:
+		return "", false
+	}
+}
+
+type overlapModeKey struct{}
+
+// WithOverlapMode задаёт режим проверки пересечений для Create/Update в рамках запроса.
+func WithOverlapMode(ctx context.Context, mode OverlapMode) context.Context {
+	return context.WithValue(ctx, overlapModeKey{}, mode)
+}
+
+// OverlapModeFromContext возвращает режим проверки пересечений. Без явного режима проверка не выполняется.
+func OverlapModeFromContext(ctx context.Context) (OverlapMode, bool) {
+	mode, ok := ctx.Value(overlapModeKey{}).(OverlapMode)
+	return mode, ok
+}
+
+// OverlapError возвращается в режиме OverlapReject, если найдены пересекающиеся подписки.
+type OverlapError struct {
+	Overlaps []models.Subscription
+}
+
+func (e *OverlapError) Error() string {
+	ids := make([]string, 0, len(e.Overlaps))
+	for _, item := range e.Overlaps {
+		ids = append(ids, strconv.Itoa(item.ID))
+	}
+	return fmt.Sprintf("%s: подписка пересекается с существующими (id: %s)", ErrConflict, strings.Join(ids, ", "))
+}
+
+func (e *OverlapError) Unwrap() error {
+	return ErrConflict
+}
+
+// DuplicateGroup - пересекающиеся подписки одного пользователя на один сервис.
+type DuplicateGroup struct {
+	UserID        string
+	ServiceName   string
+	Subscriptions []models.Subscription
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+)
+
+// FromRepo переводит ошибки ограничений хранилища в ошибки валидации и конфликта,
+// чтобы клиент получил 400/409, а не 500. Ошибка поля ищется в constraints по имени ограничения;
+// нарушение внешнего ключа без записи в constraints даёт foreignKey, если он задан.
+func FromRepo(err error, foreignKey FieldError, constraints map[string]FieldError) error {
+	var constraintErr *infra.ConstraintError
+	if !errors.As(err, &constraintErr) {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, infra.ErrInvalidInput):
+		return NewValidationError(FieldError{
+			Code: CodeInvalidFormat,
+			Key:  i18n.MsgInvalidInput,
+		})
+	case errors.Is(err, infra.ErrCheckViolation), errors.Is(err, infra.ErrForeignKey):
+		if field, ok := constraints[constraintErr.Constraint]; ok {
+			return NewValidationError(field)
+		}
+		if errors.Is(err, infra.ErrForeignKey) && foreignKey.Key != "" {
+			return NewValidationError(foreignKey)
+		}
+		return NewValidationError(FieldError{
+			Code: CodeInvalidValue,
+			Key:  i18n.MsgCheckViolation,
+		})
+	case errors.Is(err, infra.ErrUniqueViolation), errors.Is(err, infra.ErrSerialization):
+		return fmt.Errorf("%w: %v", ErrConflict, err)
+	default:
+		return err
+	}
+}
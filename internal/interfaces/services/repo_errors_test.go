@@ -0,0 +1,54 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+)
+
+func TestFromRepo(t *testing.T) {
+	fk := services.FieldError{Field: "team_id", Code: services.CodeInvalidValue, Key: "team.not_found"}
+	named := services.FieldError{Field: "price", Code: services.CodeNegative, Key: "field.negative"}
+	constraints := map[string]services.FieldError{"subscriptions_price_check": named}
+
+	tests := []struct {
+		name       string
+		err        error
+		foreignKey services.FieldError
+		wantField  *services.FieldError
+		wantIs     error
+	}{
+		{name: "не ошибка ограничения", err: errors.New("boom")},
+		{name: "известное ограничение", err: &infra.ConstraintError{Err: infra.ErrCheckViolation, Constraint: "subscriptions_price_check"}, wantField: &named},
+		{name: "внешний ключ", err: &infra.ConstraintError{Err: infra.ErrForeignKey, Constraint: "budgets_team_id_fkey"}, foreignKey: fk, wantField: &fk},
+		{name: "внешний ключ без поля", err: &infra.ConstraintError{Err: infra.ErrForeignKey}, wantIs: services.ErrValidation},
+		{name: "неизвестная проверка", err: &infra.ConstraintError{Err: infra.ErrCheckViolation}, foreignKey: fk, wantIs: services.ErrValidation},
+		{name: "некорректный ввод", err: &infra.ConstraintError{Err: infra.ErrInvalidInput}, wantIs: services.ErrValidation},
+		{name: "уникальность", err: &infra.ConstraintError{Err: infra.ErrUniqueViolation}, wantIs: services.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := services.FromRepo(tt.err, tt.foreignKey, constraints)
+
+			switch {
+			case tt.wantField != nil:
+				var verr *services.ValidationError
+				require.ErrorAs(t, err, &verr)
+				require.Equal(t, []services.FieldError{*tt.wantField}, verr.Fields)
+			case tt.wantIs != nil:
+				require.ErrorIs(t, err, tt.wantIs)
+				var verr *services.ValidationError
+				if errors.As(err, &verr) {
+					require.NotEqual(t, fk, verr.Fields[0])
+				}
+			default:
+				require.Equal(t, tt.err, err)
+			}
+		})
+	}
+}
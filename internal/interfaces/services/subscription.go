@@ -2,18 +2,106 @@ package services
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/sunr3d/subscription-aggregator/models"
 )
 
 type ListFilter struct {
-	UserID         string
-	HasUserID      bool
-	ServiceName    string
-	HasServiceName bool
-	Limit          int
-	Offset         int
+	// UserIDs/ServiceNames - подписка принадлежит одному из пользователей/сервисов; пустой - любой.
+	UserIDs      []string
+	ServiceNames []string
+	// Categories - подписка относится к одной из категорий; пустой - любая.
+	Categories []string
+	// IncludeShared - UserIDs совпадают и с участниками подписки. TotalCost и TotalCostByTeam с UserIDs
+	// всегда учитывают участников и считают только доли этих пользователей (models.Subscription.Shares).
+	IncludeShared bool
+	// TeamIDs/OrganizationIDs - подписка закреплена за одной из команд / командой одной из организаций.
+	TeamIDs         []int
+	OrganizationIDs []int
+	// VisibleTo - только подписки пользователя и его команд, заполняется при включённой авторизации.
+	VisibleTo *Visibility
+	// CreatedAfter/UpdatedAfter - строго позже указанного момента (инкрементальная выгрузка).
+	CreatedAfter    time.Time
+	HasCreatedAfter bool
+	UpdatedAfter    time.Time
+	HasUpdatedAfter bool
+	// Диапазон цены, включительно.
+	PriceMin    int
+	HasPriceMin bool
+	PriceMax    int
+	HasPriceMax bool
+	// Диапазон месяца начала подписки, включительно.
+	StartFrom    time.Time
+	HasStartFrom bool
+	StartTo      time.Time
+	HasStartTo   bool
+	// ActiveAt - подписка действует в этом месяце.
+	ActiveAt    time.Time
+	HasActiveAt bool
+	// TrialEndsWithin - пробный период заканчивается в текущем месяце или в ближайшие TrialEndsWithin месяцев.
+	TrialEndsWithin    int
+	HasTrialEndsWithin bool
+	// Query - поиск по названию сервиса (подстрока или нечёткое совпадение).
+	Query    string
+	HasQuery bool
+	// Status - статус подписки относительно текущего месяца, пустой - любой.
+	// active (как и ActiveAt) не включает приостановленные подписки.
+	Status Status
+	// Sort - ключи сортировки по порядку приоритета, пустой - по убыванию id.
+	Sort   []SortKey
+	Limit  int
+	Offset int
+}
+
+// Visibility - подписки, доступные пользователю: его собственные, подписки его команд
+// и подписки, в которых он участник.
+type Visibility struct {
+	UserID  string
+	TeamIDs []int
+}
+
+// TeamCost - сумма подписок команды за период; TeamID == nil - подписки без команды.
+type TeamCost struct {
+	TeamID    *int
+	TotalCost int
+}
+
+// MonthCost - сумма подписок за один месяц прогноза.
+type MonthCost struct {
+	Month     time.Time
+	TotalCost int
+}
+
+// MaxForecastMonths - максимальная длина прогноза в месяцах.
+const MaxForecastMonths = 60
+
+// SortKey - поле сортировки списка и направление.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// SortFields - поля, по которым разрешена сортировка.
+var SortFields = []string{"price", "start_date", "end_date", "service_name", "created_at"}
+
+// Status - статус подписки относительно текущего месяца.
+type Status string
+
+const (
+	StatusActive Status = "active"
+	StatusEnded  Status = "ended"
+	StatusFuture Status = "future"
+)
+
+func ParseStatus(s string) (Status, bool) {
+	switch status := Status(strings.ToLower(strings.TrimSpace(s))); status {
+	case StatusActive, StatusEnded, StatusFuture:
+		return status, true
+	default:
+		return "", false
+	}
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.53.2 --name=SubscriptionService --output=../../../mocks --filename=mock_subscription_service.go --with-expecter
@@ -27,4 +115,26 @@ type SubscriptionService interface {
 
 	// Custom
 	TotalCost(ctx context.Context, start, end time.Time, filter ListFilter) (int, error)
+	TotalCostByTeam(ctx context.Context, start, end time.Time, filter ListFilter) ([]TeamCost, error)
+	FindOverlaps(ctx context.Context, data models.Subscription) ([]models.Subscription, error)
+	Duplicates(ctx context.Context, filter ListFilter) ([]DuplicateGroup, error)
+	// Forecast - помесячные суммы на months месяцев начиная с from с учётом дат окончания
+	// и запланированных изменений цены; доли участников считаются так же, как в TotalCost.
+	// Нулевой from - текущий месяц.
+	Forecast(ctx context.Context, from time.Time, months int, filter ListFilter) ([]MonthCost, error)
+
+	// Участники подписки
+	SetParticipant(ctx context.Context, id int, data models.Participant) error
+	RemoveParticipant(ctx context.Context, id int, userID string) error
+
+	// Запланированные изменения цены
+	SetPriceChange(ctx context.Context, id int, data models.PriceChange) error
+	RemovePriceChange(ctx context.Context, id int, effectiveFrom time.Time) error
+
+	// Паузы: Pause приостанавливает подписку с месяца data.Start (по data.End или бессрочно),
+	// Resume возобновляет бессрочно приостановленную подписку с месяца from.
+	// Нулевые data.Start и from - текущий месяц.
+	// Пауза, пересекающаяся с другой, - ошибка валидации; Resume без бессрочной паузы - ErrConflict.
+	Pause(ctx context.Context, id int, data models.Pause) error
+	Resume(ctx context.Context, id int, from time.Time) error
 }
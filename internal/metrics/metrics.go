@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "subscription_aggregator"
+
+// Registry - реестр метрик приложения, отдаётся на admin порту.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Количество HTTP запросов по маршруту и коду ответа.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Длительность обработки HTTP запросов по маршруту и коду ответа.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	SubscriptionsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "subscriptions_created_total",
+		Help:      "Количество созданных подписок.",
+	})
+
+	DBRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_retries_total",
+		Help:      "Количество повторов запросов к БД после временных ошибок по операции.",
+	}, []string{"operation"})
+
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Обращения к кешу сервиса по операции и результату (hit, miss, bypass).",
+	}, []string{"operation", "result"})
+
+	BudgetAlerts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "budget_alerts_total",
+		Help:      "Количество отправленных уведомлений о бюджетах по порогу (в процентах).",
+	}, []string{"threshold"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		SubscriptionsCreated,
+		DBRetries,
+		CacheRequests,
+		BudgetAlerts,
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
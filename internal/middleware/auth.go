@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+)
+
+// UserIDHeader - заголовок с UUID пользователя, от имени которого выполняется запрос.
+const UserIDHeader = "X-User-ID"
+
+// Auth при enabled требует заголовок X-User-ID с UUID пользователя и кладёт пользователя в контекст;
+// права по членству в командах проверяет сервисный слой. Пробы /healthz и /readyz доступны без него.
+// Подлинность X-User-ID должен гарантировать шлюз перед сервисом.
+func Auth(enabled bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !enabled {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID := strings.TrimSpace(r.Header.Get(UserIDHeader))
+			if uuid.Validate(userID) != nil {
+				httpx.WriteError(w, r, http.StatusUnauthorized, httpx.CodeUnauthorized, i18n.MsgAuthRequired)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(services.WithActor(r.Context(), strings.ToLower(userID))))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+)
+
+func TestAuth(t *testing.T) {
+	var (
+		actor string
+		ok    bool
+	)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor, ok = services.ActorFromContext(r.Context())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Auth(false)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/subscriptions", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.False(t, ok)
+	})
+
+	t.Run("missing header", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Auth(true)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/subscriptions", nil))
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Equal(t, httpx.ProblemContentType, rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("probe without header", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		Auth(true)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("valid header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+		req.Header.Set(UserIDHeader, "60601FEE-2BF1-4721-AE6F-7636E79A0CBA")
+		rec := httptest.NewRecorder()
+		Auth(true)(next).ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.True(t, ok)
+		require.Equal(t, "60601fee-2bf1-4721-ae6f-7636e79a0cba", actor)
+	})
+}
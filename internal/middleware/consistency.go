@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+)
+
+// ReadConsistencyHeader - заголовок, которым клиент требует читать с primary (read-your-writes).
+const ReadConsistencyHeader = "X-Read-Consistency"
+
+// ReadConsistency направляет чтения запроса на primary, если клиент передал
+// X-Read-Consistency: primary, а также для всех изменяющих запросов:
+// PATCH читает запись перед обновлением, и отстающая реплика откатила бы свежие изменения.
+func ReadConsistency() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			primary := strings.EqualFold(strings.TrimSpace(r.Header.Get(ReadConsistencyHeader)), "primary")
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				primary = true
+			}
+			if primary {
+				r = r.WithContext(infra.WithPrimaryReads(r.Context()))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
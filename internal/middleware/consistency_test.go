@@ -0,0 +1,41 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/middleware"
+)
+
+func TestReadConsistency(t *testing.T) {
+	cases := []struct {
+		method  string
+		header  string
+		primary bool
+	}{
+		{http.MethodGet, "", false},
+		{http.MethodGet, "primary", true},
+		{http.MethodGet, "Primary", true},
+		{http.MethodGet, "replica", false},
+		{http.MethodPatch, "", true},
+		{http.MethodPost, "", true},
+	}
+	for _, tc := range cases {
+		var got bool
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = infra.PrimaryReadsFromContext(r.Context())
+		})
+
+		r := httptest.NewRequest(tc.method, "/subscriptions", nil)
+		if tc.header != "" {
+			r.Header.Set(middleware.ReadConsistencyHeader, tc.header)
+		}
+		middleware.ReadConsistency()(next).ServeHTTP(httptest.NewRecorder(), r)
+
+		require.Equal(t, tc.primary, got, "%s %q", tc.method, tc.header)
+	}
+}
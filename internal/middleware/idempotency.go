@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/logger"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+// Idempotency обеспечивает идемпотентность POST запросов по заголовку Idempotency-Key.
+// Повтор с тем же ключом и телом воспроизводит сохранённый ответ,
+// тот же ключ с другим запросом отклоняется с 422.
+// Тело читается в память целиком, поэтому его размер ограничен maxBody байтами, больше - 413.
+func Idempotency(store infra.IdempotencyStore, ttl time.Duration, maxBody int64, log *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := strings.TrimSpace(r.Header.Get(IdempotencyKeyHeader))
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			log := logger.FromContext(r.Context(), log)
+
+			if len(key) > maxIdempotencyKeyLength {
+				httpx.WriteError(w, r, http.StatusBadRequest, httpx.CodeIdempotencyKeyInvalid, i18n.MsgIdempotencyKeyTooLong)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				httpx.WriteError(w, r, http.StatusRequestEntityTooLarge, httpx.CodeRequestTooLarge, i18n.MsgBodyTooLarge, maxBody)
+				return
+			}
+			if err != nil {
+				httpx.WriteError(w, r, http.StatusBadRequest, httpx.CodeInvalidJSON, i18n.MsgBodyUnreadable)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			hash := requestHash(r, body)
+
+			rec, reserved, err := store.Reserve(r.Context(), key, hash, ttl)
+			if err != nil {
+				log.Error("Idempotency: не удалось зарезервировать ключ", zap.Error(err), zap.String("key", key))
+				httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "")
+				return
+			}
+
+			if !reserved {
+				switch {
+				case rec.RequestHash != hash:
+					httpx.WriteError(w, r, http.StatusUnprocessableEntity, httpx.CodeIdempotencyKeyReused, "")
+				case !rec.Completed():
+					httpx.WriteError(w, r, http.StatusConflict, httpx.CodeIdempotencyKeyInProgress, "")
+				default:
+					if rec.ContentType != "" {
+						w.Header().Set("Content-Type", rec.ContentType)
+					}
+					w.Header().Set(IdempotentReplayedHeader, "true")
+					w.WriteHeader(rec.StatusCode)
+					if _, err := w.Write(rec.ResponseBody); err != nil {
+						log.Warn("Idempotency: клиент закрыл соединение, ответ не был отправлен", zap.Error(err))
+					}
+				}
+				return
+			}
+
+			// Ответ сохраняется даже если клиент отключился, иначе повтор не сможет его получить.
+			storeCtx := context.WithoutCancel(r.Context())
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				if err := store.Release(storeCtx, key); err != nil {
+					log.Error("Idempotency: не удалось снять резерв ключа", zap.Error(err), zap.String("key", key))
+				}
+			}()
+
+			rw := newResponseWriter(w)
+			rw.body = &bytes.Buffer{}
+			next.ServeHTTP(rw, r)
+
+			// Ошибки сервера не сохраняем: повтор должен иметь шанс выполниться успешно.
+			if rw.Status() >= http.StatusInternalServerError {
+				return
+			}
+			if err := store.Complete(storeCtx, key, rw.Status(), rw.Header().Get("Content-Type"), rw.body.Bytes()); err != nil {
+				log.Error("Idempotency: не удалось сохранить ответ", zap.Error(err), zap.String("key", key))
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// RunIdempotencyPurge удаляет истёкшие ключи идемпотентности сразу и затем каждые interval, пока не отменён ctx.
+// Ошибки пишутся в лог и не останавливают цикл.
+func RunIdempotencyPurge(ctx context.Context, store infra.IdempotencyStore, interval time.Duration, log *zap.Logger) error {
+	log = log.With(zap.String("component", "middleware.IdempotencyPurge"))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := store.PurgeExpired(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			log.Warn("Не удалось удалить истёкшие ключи идемпотентности", zap.Error(err))
+		case n > 0:
+			log.Debug("Удалены истёкшие ключи идемпотентности", zap.Int64("count", n))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(r.URL.RequestURI()))
+	h.Write([]byte{'\n'})
+	// Пользователь входит в хеш: чужой Idempotency-Key даёт 422, а не сохранённый ответ другого пользователя.
+	if actor, ok := services.ActorFromContext(r.Context()); ok {
+		h.Write([]byte(actor))
+		h.Write([]byte{'\n'})
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
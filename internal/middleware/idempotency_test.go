@@ -0,0 +1,146 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/middleware"
+	"github.com/sunr3d/subscription-aggregator/mocks"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func newIdempotentReq(key, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/subscriptions", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set(middleware.IdempotencyKeyHeader, key)
+	return r
+}
+
+func TestIdempotency_FirstRequest_StoresResponse(t *testing.T) {
+	store := mocks.NewIdempotencyStore(t)
+	calls := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1}`))
+	})
+
+	store.EXPECT().Reserve(mock.Anything, "k-1", mock.AnythingOfType("string"), time.Hour).
+		Return(models.IdempotencyRecord{}, true, nil)
+	store.EXPECT().Complete(mock.Anything, "k-1", http.StatusCreated, "application/json", []byte(`{"id":1}`)).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, time.Hour, 1<<20, zap.NewNop())(next).ServeHTTP(w, newIdempotentReq("k-1", `{"a":1}`))
+
+	require.Equal(t, 1, calls)
+	require.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestIdempotency_ServerError_ReleasesKey(t *testing.T) {
+	store := mocks.NewIdempotencyStore(t)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	store.EXPECT().Reserve(mock.Anything, "k-1", mock.AnythingOfType("string"), time.Hour).
+		Return(models.IdempotencyRecord{}, true, nil)
+	store.EXPECT().Release(mock.Anything, "k-1").Return(nil)
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, time.Hour, 1<<20, zap.NewNop())(next).ServeHTTP(w, newIdempotentReq("k-1", `{"a":1}`))
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	store := mocks.NewIdempotencyStore(t)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("обработчик не должен вызываться при повторе")
+	})
+
+	store.EXPECT().Reserve(mock.Anything, "k-1", mock.AnythingOfType("string"), time.Hour).
+		RunAndReturn(func(_ context.Context, key, requestHash string, _ time.Duration) (models.IdempotencyRecord, bool, error) {
+			return models.IdempotencyRecord{
+				Key:          key,
+				RequestHash:  requestHash,
+				StatusCode:   http.StatusCreated,
+				ContentType:  "application/json",
+				ResponseBody: []byte(`{"id":1}`),
+			}, false, nil
+		})
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, time.Hour, 1<<20, zap.NewNop())(next).ServeHTTP(w, newIdempotentReq("k-1", `{"a":1}`))
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, `{"id":1}`, w.Body.String())
+	require.Equal(t, "true", w.Header().Get(middleware.IdempotentReplayedHeader))
+}
+
+func TestIdempotency_DifferentBody_422(t *testing.T) {
+	store := mocks.NewIdempotencyStore(t)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("обработчик не должен вызываться при несовпадении тела")
+	})
+
+	store.EXPECT().Reserve(mock.Anything, "k-1", mock.AnythingOfType("string"), time.Hour).
+		Return(models.IdempotencyRecord{Key: "k-1", RequestHash: "other", StatusCode: http.StatusCreated}, false, nil)
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, time.Hour, 1<<20, zap.NewNop())(next).ServeHTTP(w, newIdempotentReq("k-1", `{"a":2}`))
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestIdempotency_InProgress_409(t *testing.T) {
+	store := mocks.NewIdempotencyStore(t)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("обработчик не должен вызываться, пока исходный запрос не завершён")
+	})
+
+	store.EXPECT().Reserve(mock.Anything, "k-1", mock.AnythingOfType("string"), time.Hour).
+		RunAndReturn(func(_ context.Context, key, requestHash string, _ time.Duration) (models.IdempotencyRecord, bool, error) {
+			return models.IdempotencyRecord{Key: key, RequestHash: requestHash}, false, nil
+		})
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, time.Hour, 1<<20, zap.NewNop())(next).ServeHTTP(w, newIdempotentReq("k-1", `{"a":1}`))
+
+	require.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestIdempotency_BodyTooLarge_413(t *testing.T) {
+	store := mocks.NewIdempotencyStore(t)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("обработчик не должен вызываться для слишком большого тела")
+	})
+
+	w := httptest.NewRecorder()
+	middleware.Idempotency(store, time.Hour, 4, zap.NewNop())(next).ServeHTTP(w, newIdempotentReq("k-1", `{"a":1}`))
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	require.Contains(t, w.Body.String(), "request_too_large")
+}
+
+func TestRunIdempotencyPurge_PurgesUntilCancelled(t *testing.T) {
+	store := mocks.NewIdempotencyStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	store.EXPECT().PurgeExpired(mock.Anything).
+		RunAndReturn(func(context.Context) (int64, error) {
+			cancel()
+			return 3, nil
+		}).Once()
+
+	require.NoError(t, middleware.RunIdempotencyPurge(ctx, store, time.Hour, zap.NewNop()))
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+)
+
+// Language выбирает язык сообщений по Accept-Language (по умолчанию русский) и кладёт его в контекст.
+func Language() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang := i18n.Parse(r.Header.Get("Accept-Language"))
+			w.Header().Add("Vary", "Accept-Language")
+			w.Header().Set("Content-Language", string(lang))
+			next.ServeHTTP(w, r.WithContext(i18n.WithLang(r.Context(), lang)))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/subscription-aggregator/internal/metrics"
+)
+
+// Metrics считает запросы и их длительность по шаблону маршрута mux (а не по сырому пути),
+// чтобы id в пути не раздували кардинальность меток.
+func Metrics(mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			route := routePattern(mux, r)
+
+			rw := newResponseWriter(w)
+			// Запись в defer: запрос, упавший с паникой, считается как 500, а паника
+			// уходит дальше в Recovery, который и пишет ответ клиенту.
+			defer func() {
+				code := rw.Status()
+				rec := recover()
+				if rec != nil {
+					code = http.StatusInternalServerError
+				}
+				method := methodLabel(r.Method)
+				status := strconv.Itoa(code)
+				metrics.HTTPRequests.WithLabelValues(method, route, status).Inc()
+				metrics.HTTPDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+				if rec != nil {
+					panic(rec)
+				}
+			}()
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// routePattern возвращает путь из шаблона маршрута без метода ("GET /subscriptions/{id}" -> "/subscriptions/{id}").
+func routePattern(mux *http.ServeMux, r *http.Request) string {
+	_, pattern := mux.Handler(r)
+	if pattern == "" {
+		return "unmatched"
+	}
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		return path
+	}
+	return pattern
+}
+
+// methodLabel сводит нестандартные методы к "other": метод приходит от клиента и не должен раздувать кардинальность.
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions, http.MethodConnect, http.MethodTrace:
+		return method
+	default:
+		return "other"
+	}
+}
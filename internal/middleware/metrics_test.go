@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/metrics"
+	"github.com/sunr3d/subscription-aggregator/internal/middleware"
+)
+
+func TestMetrics_Labels(t *testing.T) {
+	metrics.HTTPRequests.Reset()
+	metrics.HTTPDuration.Reset()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.HTTPRequests, metrics.HTTPDuration)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /subscriptions/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	handler := middleware.Metrics(mux)(mux)
+
+	for _, r := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/subscriptions/1", nil),
+		httptest.NewRequest(http.MethodGet, "/subscriptions/2", nil),
+		httptest.NewRequest("PROPFIND", "/subscriptions/1", nil),
+		httptest.NewRequest("X-RANDOM-1", "/nope", nil),
+	} {
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	require.Equal(t, 2.0, testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(http.MethodGet, "/subscriptions/{id}", "200")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("other", "unmatched", "405")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues("other", "unmatched", "404")))
+
+	n, err := testutil.GatherAndCount(reg, "subscription_aggregator_http_requests_total")
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	n, err = testutil.GatherAndCount(reg, "subscription_aggregator_http_request_duration_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+}
+
+func TestMetrics_PanicCountedAs500(t *testing.T) {
+	metrics.HTTPRequests.Reset()
+	metrics.HTTPDuration.Reset()
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(metrics.HTTPRequests, metrics.HTTPDuration)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /subscriptions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	// Порядок как в entrypoint: Recovery снаружи Metrics.
+	handler := middleware.Recovery(zap.NewNop())(middleware.Metrics(mux)(mux))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/subscriptions/1", nil))
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.HTTPRequests.WithLabelValues(http.MethodGet, "/subscriptions/{id}", "500")))
+	n, err := testutil.GatherAndCount(reg, "subscription_aggregator_http_request_duration_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestMetrics_PanicPropagates(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /subscriptions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	require.PanicsWithValue(t, "boom", func() {
+		middleware.Metrics(mux)(mux).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/subscriptions/1", nil))
+	})
+}
@@ -1,7 +1,10 @@
 package middleware
 
 import (
+	"fmt"
+	"net"
 	"net/http"
+	"net/netip"
 	"runtime/debug"
 	"strings"
 	"time"
@@ -9,31 +12,48 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/internal/logger"
 )
 
-func ReqLogger(log *zap.Logger) func(http.Handler) http.Handler {
+// ReqLogger пишет access лог. X-Forwarded-For учитывается только от trustedProxies.
+func ReqLogger(log *zap.Logger, trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
-			next.ServeHTTP(w, r)
-			log.Info("Входящий HTTP запрос",
+			rw := newResponseWriter(w)
+			next.ServeHTTP(rw, r)
+
+			fields := []zap.Field{
 				zap.String("method", r.Method),
 				zap.String("url", r.URL.Path),
+				zap.Int("status", rw.Status()),
+				zap.Int("bytes", rw.size),
+				zap.String("client_ip", clientIP(r, trustedProxies)),
 				zap.Int64("duration_ms", time.Since(start).Milliseconds()),
-			)
+			}
+			if userID := requestUserID(r); userID != "" {
+				fields = append(fields, zap.String("user_id", userID))
+			}
+			logger.FromContext(r.Context(), log).Info("Входящий HTTP запрос", fields...)
 		})
 	}
 }
 
+// JSONValidator требует JSON Content-Type у запросов с телом.
+// Запросы без тела (Content-Length: 0) пропускаются без проверки заголовка.
 func JSONValidator(log *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			switch r.Method {
 			case http.MethodPost, http.MethodPut, http.MethodPatch:
+				if r.ContentLength == 0 {
+					break
+				}
 				ct := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Type")))
 				if !httpx.IsJSON(ct) {
-					if err := httpx.HttpError(w, http.StatusUnsupportedMediaType, "Ожидается Content-Type: application/json"); err != nil {
-						log.Warn("JSONValidator: не удалось записать ошибку",
+					if err := httpx.WriteError(w, r, http.StatusUnsupportedMediaType, httpx.CodeUnsupportedMediaType, i18n.MsgExpectedJSON); err != nil {
+						logger.FromContext(r.Context(), log).Warn("JSONValidator: не удалось записать ошибку",
 							zap.Error(err),
 							zap.String("method", r.Method),
 							zap.String("url", r.URL.Path),
@@ -53,13 +73,14 @@ func Recovery(log *zap.Logger) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
 				if rec := recover(); rec != nil {
+					log := logger.FromContext(r.Context(), log)
 					log.Error("Паника в обработчике запроса",
 						zap.Any("rec", rec),
 						zap.String("stack", string(debug.Stack())),
 						zap.String("url", r.URL.Path),
 						zap.String("method", r.Method),
 					)
-					if err := httpx.HttpError(w, http.StatusInternalServerError, "Внутренняя ошибка сервера"); err != nil {
+					if err := httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, ""); err != nil {
 						log.Warn("Recovery: не удалось записать ошибку",
 							zap.Error(err),
 							zap.String("method", r.Method),
@@ -72,3 +93,71 @@ func Recovery(log *zap.Logger) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// ParseTrustedProxies разбирает список доверенных прокси: CIDR или отдельные IP адреса.
+func ParseTrustedProxies(list []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(list))
+	for _, raw := range list {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(raw); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(raw)
+		if err != nil {
+			return nil, fmt.Errorf("некорректный адрес доверенного прокси %q", raw)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+	}
+	return prefixes, nil
+}
+
+// clientIP - адрес клиента. Если запрос пришёл от доверенного прокси, X-Forwarded-For
+// читается справа налево до первого недоверенного адреса: левые значения клиент может подделать.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !isTrusted(host, trustedProxies) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		host = hop
+		if !isTrusted(hop, trustedProxies) {
+			break
+		}
+	}
+	return host
+}
+
+func isTrusted(ip string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestUserID - пользователь запроса: заголовок X-User-ID или параметр ?user_id.
+func requestUserID(r *http.Request) string {
+	if userID := strings.TrimSpace(r.Header.Get("X-User-ID")); userID != "" {
+		return userID
+	}
+	return strings.TrimSpace(r.URL.Query().Get("user_id"))
+}
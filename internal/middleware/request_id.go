@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/logger"
+)
+
+const maxRequestIDLength = 128
+
+// RequestID принимает X-Request-ID клиента или генерирует новый, возвращает его в ответе
+// и кладёт в контекст id и логгер запроса с полем request_id.
+func RequestID(log *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := strings.TrimSpace(r.Header.Get(httpx.RequestIDHeader))
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+			w.Header().Set(httpx.RequestIDHeader, id)
+
+			ctx := httpx.WithRequestID(r.Context(), id)
+			ctx = logger.WithContext(ctx, log.With(zap.String("request_id", id)))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// validRequestID пропускает только короткие id из безопасных символов, чтобы клиент не мог засорить логи.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
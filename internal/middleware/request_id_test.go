@@ -0,0 +1,116 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/sunr3d/subscription-aggregator/internal/httpx"
+	"github.com/sunr3d/subscription-aggregator/internal/logger"
+	"github.com/sunr3d/subscription-aggregator/internal/middleware"
+)
+
+func TestRequestID_AcceptsClientID(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	var ctxID string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxID = httpx.RequestIDFromContext(r.Context())
+		logger.FromContext(r.Context(), zap.NewNop()).Info("из обработчика")
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+	r.Header.Set(httpx.RequestIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	middleware.RequestID(zap.New(core))(next).ServeHTTP(w, r)
+
+	require.Equal(t, "req-123", ctxID)
+	require.Equal(t, "req-123", w.Header().Get(httpx.RequestIDHeader))
+	require.Equal(t, 1, logs.Len())
+	require.Equal(t, "req-123", logs.All()[0].ContextMap()["request_id"])
+}
+
+func TestRequestID_GeneratesWhenMissingOrInvalid(t *testing.T) {
+	for _, header := range []string{"", "bad id\nwith newline", strings.Repeat("a", 200)} {
+		var ctxID string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctxID = httpx.RequestIDFromContext(r.Context())
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+		if header != "" {
+			r.Header.Set(httpx.RequestIDHeader, header)
+		}
+		w := httptest.NewRecorder()
+		middleware.RequestID(zap.NewNop())(next).ServeHTTP(w, r)
+
+		require.Len(t, ctxID, 32)
+		require.NotEqual(t, header, ctxID)
+		require.Equal(t, ctxID, w.Header().Get(httpx.RequestIDHeader))
+	}
+}
+
+func TestReqLogger_StatusAndBytes(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1}`))
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/subscriptions?user_id=u-1", nil)
+	r.RemoteAddr = "10.0.0.1:5555"
+	w := httptest.NewRecorder()
+	middleware.ReqLogger(zap.New(core), nil)(next).ServeHTTP(w, r)
+
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	require.EqualValues(t, http.StatusCreated, fields["status"])
+	require.EqualValues(t, 8, fields["bytes"])
+	require.Equal(t, "10.0.0.1", fields["client_ip"])
+	require.Equal(t, "u-1", fields["user_id"])
+}
+
+func TestReqLogger_ClientIP(t *testing.T) {
+	trusted, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		remote string
+		xff    []string
+		want   string
+	}{
+		{name: "без прокси", remote: "203.0.113.7:5555", want: "203.0.113.7"},
+		{name: "XFF от недоверенного адреса игнорируется", remote: "203.0.113.7:5555", xff: []string{"1.2.3.4"}, want: "203.0.113.7"},
+		{name: "доверенный прокси", remote: "10.0.0.1:5555", xff: []string{"198.51.100.2"}, want: "198.51.100.2"},
+		{name: "подделанный левый адрес", remote: "10.0.0.1:5555", xff: []string{"1.2.3.4, 198.51.100.2"}, want: "198.51.100.2"},
+		{name: "цепочка доверенных прокси", remote: "10.0.0.1:5555", xff: []string{"198.51.100.2, 192.168.1.1", "10.1.1.1"}, want: "198.51.100.2"},
+		{name: "все адреса доверенные", remote: "10.0.0.1:5555", xff: []string{"10.2.2.2"}, want: "10.2.2.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			r := httptest.NewRequest(http.MethodGet, "/subscriptions", nil)
+			r.RemoteAddr = tt.remote
+			for _, v := range tt.xff {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+
+			middleware.ReqLogger(zap.New(core), trusted)(http.NotFoundHandler()).ServeHTTP(httptest.NewRecorder(), r)
+
+			require.Equal(t, tt.want, logs.All()[0].ContextMap()["client_ip"])
+		})
+	}
+}
+
+func TestParseTrustedProxies_Invalid(t *testing.T) {
+	_, err := middleware.ParseTrustedProxies([]string{"10.0.0.0/8", "proxy.local"})
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// responseWriter запоминает код ответа, размер и, при необходимости, тело ответа.
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+	body   *bytes.Buffer
+}
+
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: w}
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	if rw.status == 0 {
+		rw.status = code
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	n, err := rw.ResponseWriter.Write(b)
+	rw.size += n
+	if rw.body != nil {
+		rw.body.Write(b[:n])
+	}
+	return n, err
+}
+
+func (rw *responseWriter) Status() int {
+	if rw.status == 0 {
+		return http.StatusOK
+	}
+	return rw.status
+}
+
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
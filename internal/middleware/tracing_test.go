@@ -0,0 +1,78 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sunr3d/subscription-aggregator/internal/middleware"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+	return sr
+}
+
+func TestTracing_SpanPerRoute_ContinuesIncomingTrace(t *testing.T) {
+	sr := setupRecorder(t)
+
+	var handlerSC trace.SpanContext
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /subscriptions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		handlerSC = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/subscriptions/42", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+
+	middleware.Tracing(mux)(mux).ServeHTTP(w, r)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	require.Equal(t, "GET /subscriptions/{id}", span.Name())
+	require.Equal(t, trace.SpanKindServer, span.SpanKind())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	require.Equal(t, span.SpanContext().SpanID(), handlerSC.SpanID())
+	require.Equal(t, codes.Unset, span.Status().Code)
+}
+
+func TestTracing_ServerError_MarksSpan(t *testing.T) {
+	sr := setupRecorder(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /subscriptions", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	w := httptest.NewRecorder()
+	middleware.Tracing(mux)(mux).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/subscriptions", nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "POST /subscriptions", spans[0].Name())
+	require.False(t, spans[0].Parent().IsValid())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+}
@@ -0,0 +1,64 @@
+// Package search - поиск по названию сервиса в памяти по тем же правилам, что и в Postgres
+// (ILIKE и триграммы pg_trgm), для хранилищ без полнотекстового поиска.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Threshold - минимальная триграммная схожесть для нечёткого совпадения,
+// как pg_trgm.similarity_threshold по умолчанию.
+const Threshold = 0.3
+
+// Score - релевантность name для запроса q и признак совпадения.
+// Совпадение - подстрока без учёта регистра или схожесть не ниже Threshold.
+// Релевантность: 2 за точное совпадение, 1 за совпадение префикса, плюс схожесть.
+func Score(name, q string) (float64, bool) {
+	n, lq := strings.ToLower(name), strings.ToLower(q)
+	sim := Similarity(name, q)
+	if !strings.Contains(n, lq) && sim < Threshold {
+		return 0, false
+	}
+
+	score := sim
+	if n == lq {
+		score += 2
+	}
+	if strings.HasPrefix(n, lq) {
+		score++
+	}
+	return score, true
+}
+
+// Similarity - доля общих триграмм двух строк, как similarity() в pg_trgm.
+func Similarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	common := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+// trigrams разбивает строку на слова из букв и цифр и дополняет каждое
+// двумя пробелами в начале и одним в конце, как pg_trgm.
+func trigrams(s string) map[string]struct{} {
+	res := make(map[string]struct{})
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			res[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+	return res
+}
@@ -0,0 +1,39 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimilarity(t *testing.T) {
+	require.InDelta(t, 1, Similarity("Netflix", "netflix"), 1e-9)
+	// pg_trgm: similarity('word', 'two words') = 0.363636
+	require.InDelta(t, 0.363636, Similarity("word", "two words"), 1e-6)
+	require.Zero(t, Similarity("", "netflix"))
+}
+
+func TestScore(t *testing.T) {
+	cases := []struct {
+		name, q string
+		match   bool
+	}{
+		{"Netflix Premium", "netflix", true},
+		{"Yandex Plus", "plus", true},
+		{"Netflix", "netflx", true},
+		{"Okko", "netflix", false},
+	}
+	for _, c := range cases {
+		_, ok := Score(c.name, c.q)
+		require.Equal(t, c.match, ok, "%s / %s", c.name, c.q)
+	}
+
+	exact, _ := Score("Netflix", "netflix")
+	prefix, _ := Score("Netflix Premium", "netflix")
+	substr, _ := Score("My Netflix", "netflix")
+	fuzzy, _ := Score("Netflix", "netflx")
+	require.Greater(t, exact, prefix)
+	require.Greater(t, prefix, substr)
+	require.Greater(t, substr, 0.0)
+	require.Greater(t, fuzzy, 0.0)
+}
@@ -14,10 +14,30 @@ type Server struct {
 	server          *http.Server
 	logger          *zap.Logger
 	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	beforeShutdown  []func()
 }
 
-func New(port string, handler http.Handler, timeout time.Duration, logger *zap.Logger) *Server {
-	return &Server{
+type Option func(*Server)
+
+// WithBeforeShutdown регистрирует функцию, вызываемую при получении сигнала завершения
+// до остановки HTTP сервера (например, перевод readiness в отказ).
+func WithBeforeShutdown(f func()) Option {
+	return func(s *Server) {
+		s.beforeShutdown = append(s.beforeShutdown, f)
+	}
+}
+
+// WithDrainDelay задаёт паузу между beforeShutdown и остановкой сервера,
+// за которую балансировщик успевает снять трафик.
+func WithDrainDelay(d time.Duration) Option {
+	return func(s *Server) {
+		s.drainDelay = d
+	}
+}
+
+func New(port string, handler http.Handler, timeout time.Duration, logger *zap.Logger, opts ...Option) *Server {
+	s := &Server{
 		server: &http.Server{
 			Addr:              ":" + port,
 			Handler:           handler,
@@ -29,6 +49,10 @@ func New(port string, handler http.Handler, timeout time.Duration, logger *zap.L
 		logger:          logger,
 		shutdownTimeout: timeout,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *Server) Start(ctx context.Context) error {
@@ -47,10 +71,18 @@ func (s *Server) Start(ctx context.Context) error {
 
 	select {
 	case <-ctx.Done():
+		s.logger.Info("Получен сигнал завершения")
+		for _, f := range s.beforeShutdown {
+			f()
+		}
+		if s.drainDelay > 0 {
+			s.logger.Info("Ожидание снятия трафика перед остановкой", zap.Duration("drain_delay", s.drainDelay))
+			time.Sleep(s.drainDelay)
+		}
+
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
 		defer cancel()
 
-		s.logger.Info("Получен сигнал завершения")
 		if err := s.server.Shutdown(shutdownCtx); err != nil {
 			s.logger.Error("Ошибка при завершении работы сервера", zap.Error(err))
 			return fmt.Errorf("ошибка при завершении работы сервера: %w", err)
@@ -0,0 +1,29 @@
+package budget_service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+)
+
+// RunEvaluator проверяет бюджеты сразу и затем каждые interval, пока не отменён ctx.
+// Ошибки проверки пишутся в лог и не останавливают цикл.
+func RunEvaluator(ctx context.Context, svc services.BudgetService, interval time.Duration, log *zap.Logger) error {
+	log = log.With(zap.String("component", "budget_service.Evaluator"))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := svc.EvaluateAlerts(ctx); err != nil && ctx.Err() == nil {
+			log.Warn("Не удалось проверить бюджеты", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
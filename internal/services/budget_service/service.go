@@ -0,0 +1,257 @@
+package budget_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/metrics"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ services.BudgetService = (*budgetService)(nil)
+
+// evaluateBatch - сколько бюджетов EvaluateAlerts читает за один запрос.
+const evaluateBatch = 100
+
+var (
+	errBudgetOwner = services.FieldError{
+		Field: "user_id",
+		Code:  services.CodeInvalidValue,
+		Key:   i18n.MsgBudgetOwner,
+	}
+	errBudgetPeriod = services.FieldError{
+		Field: "period",
+		Code:  services.CodeInvalidValue,
+		Key:   i18n.MsgBudgetPeriod,
+	}
+	errBudgetAmount = services.FieldError{
+		Field: "amount",
+		Code:  services.CodeOutOfRange,
+		Key:   i18n.MsgFieldID,
+		Args:  []any{"amount"},
+	}
+	errTeamNotFound = services.FieldError{
+		Field: "team_id",
+		Code:  services.CodeInvalidValue,
+		Key:   i18n.MsgFieldTeamID,
+	}
+)
+
+// budgetService хранит бюджеты и считает расходы по ним через SubscriptionService.TotalCost,
+// поэтому доли совместных подписок, кеш и права доступа учитываются так же, как в сумме за период.
+// Если в контексте есть пользователь, бюджет пользователя доступен только ему, бюджет команды - её участникам.
+type budgetService struct {
+	repo     infra.Budgets
+	orgs     infra.Organizations
+	subs     services.SubscriptionService
+	notifier infra.Notifier
+	now      func() time.Time
+}
+
+func New(repo infra.Budgets, orgs infra.Organizations, subs services.SubscriptionService, notifier infra.Notifier) services.BudgetService {
+	return &budgetService{repo: repo, orgs: orgs, subs: subs, notifier: notifier, now: time.Now}
+}
+
+func (s *budgetService) CreateBudget(ctx context.Context, data models.Budget) (int, error) {
+	if err := validate(data); err != nil {
+		return -1, err
+	}
+	if err := s.checkAccess(ctx, data); err != nil {
+		return -1, err
+	}
+
+	id, err := s.repo.CreateBudget(ctx, data)
+	if err != nil {
+		return -1, fmt.Errorf("service CreateBudget(): %w", fromRepo(err))
+	}
+	return id, nil
+}
+
+func (s *budgetService) GetBudget(ctx context.Context, id int) (models.Budget, error) {
+	data, err := s.repo.GetBudget(ctx, id)
+	if err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return models.Budget{}, services.ErrNotFound
+		}
+		return models.Budget{}, fmt.Errorf("service GetBudget(): %w", fromRepo(err))
+	}
+	if err := s.checkAccess(ctx, data); err != nil {
+		return models.Budget{}, err
+	}
+	return data, nil
+}
+
+func (s *budgetService) Status(ctx context.Context, id int) (services.BudgetStatus, error) {
+	data, err := s.GetBudget(ctx, id)
+	if err != nil {
+		return services.BudgetStatus{}, err
+	}
+	return s.status(ctx, data)
+}
+
+func (s *budgetService) EvaluateAlerts(ctx context.Context) error {
+	var errs []error
+	for afterID := 0; ; {
+		batch, err := s.repo.ListBudgets(ctx, afterID, evaluateBatch)
+		if err != nil {
+			return errors.Join(append(errs, fmt.Errorf("service ListBudgets(): %w", err))...)
+		}
+		for _, data := range batch {
+			if err := s.evaluate(ctx, data); err != nil {
+				errs = append(errs, fmt.Errorf("бюджет %d: %w", data.ID, err))
+			}
+		}
+		if len(batch) < evaluateBatch {
+			return errors.Join(errs...)
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+}
+
+// evaluate отмечает все достигнутые за период пороги и уведомляет о наибольшем из впервые достигнутых:
+// при скачке сразу за 100% приходит одно уведомление, а не два.
+// Если уведомление не ушло, отметки снимаются, и следующая проверка попробует снова.
+func (s *budgetService) evaluate(ctx context.Context, data models.Budget) error {
+	st, err := s.status(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	var claimed []int
+	for _, threshold := range services.BudgetThresholds {
+		if st.Spent*100 < threshold*data.Amount {
+			break
+		}
+		ok, err := s.repo.ClaimAlert(ctx, data.ID, st.PeriodStart, threshold)
+		if err != nil {
+			return fmt.Errorf("service ClaimAlert(): %w", err)
+		}
+		if ok {
+			claimed = append(claimed, threshold)
+		}
+	}
+	if len(claimed) == 0 {
+		return nil
+	}
+
+	threshold := slices.Max(claimed)
+	err = s.notifier.NotifyBudget(ctx, models.BudgetAlert{
+		Budget:      data,
+		Threshold:   threshold,
+		PeriodStart: st.PeriodStart,
+		PeriodEnd:   st.PeriodEnd,
+		Spent:       st.Spent,
+	})
+	if err != nil {
+		for _, t := range claimed {
+			if rErr := s.repo.ReleaseAlert(ctx, data.ID, st.PeriodStart, t); rErr != nil {
+				err = errors.Join(err, fmt.Errorf("service ReleaseAlert(): %w", rErr))
+			}
+		}
+		return fmt.Errorf("service NotifyBudget(): %w", err)
+	}
+	metrics.BudgetAlerts.WithLabelValues(strconv.Itoa(threshold)).Inc()
+	return nil
+}
+
+// status считает расходы за текущий месяц или год: Spent - по текущий месяц, Projected - до конца периода.
+func (s *budgetService) status(ctx context.Context, data models.Budget) (services.BudgetStatus, error) {
+	now := s.now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	start, end := month, month
+	if data.Period == models.BudgetYearly {
+		start = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.Local)
+		end = time.Date(now.Year(), time.December, 1, 0, 0, 0, 0, time.Local)
+	}
+
+	filter := budgetFilter(data)
+	spent, err := s.subs.TotalCost(ctx, start, month, filter)
+	if err != nil {
+		return services.BudgetStatus{}, fmt.Errorf("service TotalCost(): %w", err)
+	}
+	projected := spent
+	if end.After(month) {
+		if projected, err = s.subs.TotalCost(ctx, start, end, filter); err != nil {
+			return services.BudgetStatus{}, fmt.Errorf("service TotalCost(): %w", err)
+		}
+	}
+
+	return services.BudgetStatus{
+		Budget:      data,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Spent:       spent,
+		Remaining:   data.Amount - spent,
+		Projected:   projected,
+		PercentUsed: spent * 100 / data.Amount,
+	}, nil
+}
+
+func budgetFilter(data models.Budget) services.ListFilter {
+	var filter services.ListFilter
+	if data.UserID != nil {
+		filter.UserIDs = []string{*data.UserID}
+	}
+	if data.TeamID != nil {
+		filter.TeamIDs = []int{*data.TeamID}
+	}
+	if data.Category != "" {
+		filter.Categories = []string{data.Category}
+	}
+	if data.ServiceName != "" {
+		filter.ServiceNames = []string{data.ServiceName}
+	}
+	return filter
+}
+
+// checkAccess - бюджет пользователя доступен ему самому, бюджет команды - её участникам.
+func (s *budgetService) checkAccess(ctx context.Context, data models.Budget) error {
+	actor, ok := services.ActorFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if data.UserID != nil {
+		if *data.UserID != actor {
+			return services.ErrForbidden
+		}
+		return nil
+	}
+
+	memberships, err := s.orgs.UserTeams(ctx, actor)
+	if err != nil {
+		return fmt.Errorf("service UserTeams(): %w", err)
+	}
+	if data.TeamID == nil || !slices.ContainsFunc(memberships, func(m models.TeamMember) bool { return m.TeamID == *data.TeamID }) {
+		return services.ErrForbidden
+	}
+	return nil
+}
+
+// validate проверяет инварианты бюджета и возвращает все нарушения сразу.
+func validate(data models.Budget) error {
+	var fields []services.FieldError
+	if (data.UserID == nil) == (data.TeamID == nil) {
+		fields = append(fields, errBudgetOwner)
+	}
+	if data.Period != models.BudgetMonthly && data.Period != models.BudgetYearly {
+		fields = append(fields, errBudgetPeriod)
+	}
+	if data.Amount <= 0 {
+		fields = append(fields, errBudgetAmount)
+	}
+	if len(fields) > 0 {
+		return services.NewValidationError(fields...)
+	}
+	return nil
+}
+
+func fromRepo(err error) error {
+	return services.FromRepo(err, errTeamNotFound, nil)
+}
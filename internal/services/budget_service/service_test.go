@@ -0,0 +1,129 @@
+package budget_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/services/budget_service"
+	"github.com/sunr3d/subscription-aggregator/mocks"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+type deps struct {
+	repo     *mocks.Budgets
+	orgs     *mocks.Organizations
+	subs     *mocks.SubscriptionService
+	notifier *mocks.Notifier
+}
+
+func newService(t *testing.T) (services.BudgetService, deps) {
+	d := deps{
+		repo:     mocks.NewBudgets(t),
+		orgs:     mocks.NewOrganizations(t),
+		subs:     mocks.NewSubscriptionService(t),
+		notifier: mocks.NewNotifier(t),
+	}
+	return budget_service.New(d.repo, d.orgs, d.subs, d.notifier), d
+}
+
+func TestService_CreateBudget_ErrValidation(t *testing.T) {
+	svc, _ := newService(t)
+
+	_, err := svc.CreateBudget(context.Background(), models.Budget{
+		UserID: ptr("u-1"),
+		TeamID: ptr(1),
+		Period: "weekly",
+	})
+	var vErr *services.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Len(t, vErr.Fields, 3)
+}
+
+func TestService_CreateBudget_ErrForbidden_NotTeamMember(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-1")
+	svc, d := newService(t)
+
+	d.orgs.EXPECT().UserTeams(ctx, "u-1").Return([]models.TeamMember{{TeamID: 2, UserID: "u-1"}}, nil)
+
+	_, err := svc.CreateBudget(ctx, models.Budget{TeamID: ptr(1), Period: models.BudgetMonthly, Amount: 1000})
+	require.ErrorIs(t, err, services.ErrForbidden)
+}
+
+func TestService_Status_Monthly(t *testing.T) {
+	ctx := context.Background()
+	svc, d := newService(t)
+
+	budget := models.Budget{ID: 1, UserID: ptr("u-1"), Period: models.BudgetMonthly, Amount: 1000, Category: "video"}
+	d.repo.EXPECT().GetBudget(ctx, 1).Return(budget, nil)
+	d.subs.EXPECT().TotalCost(ctx, mock.Anything, mock.Anything, services.ListFilter{
+		UserIDs:    []string{"u-1"},
+		Categories: []string{"video"},
+	}).Return(850, nil).Once()
+
+	st, err := svc.Status(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 850, st.Spent)
+	require.Equal(t, 150, st.Remaining)
+	require.Equal(t, 850, st.Projected)
+	require.Equal(t, 85, st.PercentUsed)
+	require.Equal(t, st.PeriodStart, st.PeriodEnd)
+}
+
+func TestService_Status_ErrNotFound(t *testing.T) {
+	ctx := context.Background()
+	svc, d := newService(t)
+
+	d.repo.EXPECT().GetBudget(ctx, 1).Return(models.Budget{}, infra.ErrNotFound)
+
+	_, err := svc.Status(ctx, 1)
+	require.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestService_EvaluateAlerts_NotifiesHighestThreshold(t *testing.T) {
+	ctx := context.Background()
+	svc, d := newService(t)
+
+	budget := models.Budget{ID: 1, UserID: ptr("u-1"), Period: models.BudgetMonthly, Amount: 1000}
+	d.repo.EXPECT().ListBudgets(ctx, 0, mock.Anything).Return([]models.Budget{budget}, nil)
+	d.subs.EXPECT().TotalCost(ctx, mock.Anything, mock.Anything, mock.Anything).Return(1200, nil)
+	d.repo.EXPECT().ClaimAlert(ctx, 1, mock.Anything, 80).Return(false, nil)
+	d.repo.EXPECT().ClaimAlert(ctx, 1, mock.Anything, 100).Return(true, nil)
+	d.notifier.EXPECT().NotifyBudget(ctx, mock.MatchedBy(func(a models.BudgetAlert) bool {
+		return a.Threshold == 100 && a.Spent == 1200 && a.Budget.ID == 1
+	})).Return(nil).Once()
+
+	require.NoError(t, svc.EvaluateAlerts(ctx))
+}
+
+func TestService_EvaluateAlerts_BelowThreshold(t *testing.T) {
+	ctx := context.Background()
+	svc, d := newService(t)
+
+	budget := models.Budget{ID: 1, UserID: ptr("u-1"), Period: models.BudgetMonthly, Amount: 1000}
+	d.repo.EXPECT().ListBudgets(ctx, 0, mock.Anything).Return([]models.Budget{budget}, nil)
+	d.subs.EXPECT().TotalCost(ctx, mock.Anything, mock.Anything, mock.Anything).Return(799, nil)
+
+	require.NoError(t, svc.EvaluateAlerts(ctx))
+}
+
+func TestService_EvaluateAlerts_ReleasesOnNotifyError(t *testing.T) {
+	ctx := context.Background()
+	svc, d := newService(t)
+
+	budget := models.Budget{ID: 1, TeamID: ptr(2), Period: models.BudgetMonthly, Amount: 1000}
+	d.repo.EXPECT().ListBudgets(ctx, 0, mock.Anything).Return([]models.Budget{budget}, nil)
+	d.subs.EXPECT().TotalCost(ctx, mock.Anything, mock.Anything, mock.Anything).Return(900, nil)
+	d.repo.EXPECT().ClaimAlert(ctx, 1, mock.Anything, 80).Return(true, nil)
+	d.notifier.EXPECT().NotifyBudget(ctx, mock.Anything).Return(errors.New("webhook down"))
+	d.repo.EXPECT().ReleaseAlert(ctx, 1, mock.Anything, 80).Return(nil).Once()
+
+	require.Error(t, svc.EvaluateAlerts(ctx))
+}
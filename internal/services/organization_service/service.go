@@ -0,0 +1,216 @@
+package organization_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ services.OrganizationService = (*organizationService)(nil)
+
+var errOrganizationNotFound = services.FieldError{
+	Field: "organization_id",
+	Code:  services.CodeInvalidValue,
+	Key:   i18n.MsgFieldOrganizationID,
+}
+
+// organizationService управляет организациями, командами и составом команд.
+// Если в контексте есть пользователь (авторизация включена), права проверяются так:
+// организацией и всеми её командами управляет владелец (создатель) организации,
+// составом команды - также её администраторы, видеть команду могут её участники.
+type organizationService struct {
+	repo infra.Organizations
+}
+
+func New(repo infra.Organizations) services.OrganizationService {
+	return &organizationService{repo: repo}
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, data models.Organization) (int, error) {
+	data.OwnerID = nil
+	if actor, ok := services.ActorFromContext(ctx); ok {
+		data.OwnerID = &actor
+	}
+
+	id, err := s.repo.CreateOrganization(ctx, data)
+	if err != nil {
+		return -1, fmt.Errorf("service CreateOrganization(): %w", fromRepo(err))
+	}
+	return id, nil
+}
+
+func (s *organizationService) GetOrganization(ctx context.Context, id int) (models.Organization, error) {
+	org, err := s.getOrganization(ctx, id)
+	if err != nil {
+		return models.Organization{}, err
+	}
+	if err := s.canViewOrganization(ctx, org); err != nil {
+		return models.Organization{}, err
+	}
+	return org, nil
+}
+
+func (s *organizationService) CreateTeam(ctx context.Context, data models.Team) (int, error) {
+	org, err := s.getOrganization(ctx, data.OrganizationID)
+	if errors.Is(err, services.ErrNotFound) {
+		return -1, services.NewValidationError(errOrganizationNotFound)
+	}
+	if err != nil {
+		return -1, err
+	}
+	if !isOwner(ctx, org) {
+		return -1, services.ErrForbidden
+	}
+
+	id, err := s.repo.CreateTeam(ctx, data)
+	if err != nil {
+		return -1, fmt.Errorf("service CreateTeam(): %w", fromRepo(err))
+	}
+	return id, nil
+}
+
+func (s *organizationService) GetTeam(ctx context.Context, id int) (models.Team, error) {
+	team, _, err := s.teamAccess(ctx, id, false)
+	return team, err
+}
+
+func (s *organizationService) ListTeams(ctx context.Context, organizationID int) ([]models.Team, error) {
+	org, err := s.getOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.canViewOrganization(ctx, org); err != nil {
+		return nil, err
+	}
+
+	teams, err := s.repo.ListTeams(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("service ListTeams(): %w", fromRepo(err))
+	}
+	return teams, nil
+}
+
+func (s *organizationService) SetMember(ctx context.Context, data models.TeamMember) error {
+	if _, _, err := s.teamAccess(ctx, data.TeamID, true); err != nil {
+		return err
+	}
+	if data.Role == "" {
+		data.Role = models.RoleMember
+	}
+
+	if err := s.repo.SetMember(ctx, data); err != nil {
+		return fmt.Errorf("service SetMember(): %w", fromRepo(err))
+	}
+	return nil
+}
+
+func (s *organizationService) RemoveMember(ctx context.Context, teamID int, userID string) error {
+	if _, _, err := s.teamAccess(ctx, teamID, true); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemoveMember(ctx, teamID, userID); err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return services.ErrNotFound
+		}
+		return fmt.Errorf("service RemoveMember(): %w", fromRepo(err))
+	}
+	return nil
+}
+
+func (s *organizationService) ListMembers(ctx context.Context, teamID int) ([]models.TeamMember, error) {
+	_, members, err := s.teamAccess(ctx, teamID, false)
+	return members, err
+}
+
+func (s *organizationService) getOrganization(ctx context.Context, id int) (models.Organization, error) {
+	org, err := s.repo.GetOrganization(ctx, id)
+	if err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return models.Organization{}, services.ErrNotFound
+		}
+		return models.Organization{}, fmt.Errorf("service GetOrganization(): %w", fromRepo(err))
+	}
+	return org, nil
+}
+
+// teamAccess возвращает команду и её состав, если пользователю разрешено её видеть
+// (manage == false) или менять её состав (manage == true).
+func (s *organizationService) teamAccess(ctx context.Context, teamID int, manage bool) (models.Team, []models.TeamMember, error) {
+	team, err := s.repo.GetTeam(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return models.Team{}, nil, services.ErrNotFound
+		}
+		return models.Team{}, nil, fmt.Errorf("service GetTeam(): %w", fromRepo(err))
+	}
+
+	members, err := s.repo.ListMembers(ctx, teamID)
+	if err != nil {
+		return models.Team{}, nil, fmt.Errorf("service ListMembers(): %w", fromRepo(err))
+	}
+
+	actor, ok := services.ActorFromContext(ctx)
+	if !ok {
+		return team, members, nil
+	}
+
+	idx := slices.IndexFunc(members, func(m models.TeamMember) bool { return m.UserID == actor })
+	allowed := idx >= 0 && (!manage || members[idx].Role == models.RoleAdmin)
+	if !allowed {
+		org, err := s.getOrganization(ctx, team.OrganizationID)
+		if err != nil {
+			return models.Team{}, nil, err
+		}
+		allowed = isOwner(ctx, org)
+	}
+	if !allowed {
+		return models.Team{}, nil, services.ErrForbidden
+	}
+	return team, members, nil
+}
+
+// canViewOrganization - организацию видят её владелец и участники любой её команды.
+func (s *organizationService) canViewOrganization(ctx context.Context, org models.Organization) error {
+	actor, ok := services.ActorFromContext(ctx)
+	if !ok || isOwner(ctx, org) {
+		return nil
+	}
+
+	memberships, err := s.repo.UserTeams(ctx, actor)
+	if err != nil {
+		return fmt.Errorf("service UserTeams(): %w", fromRepo(err))
+	}
+	if len(memberships) == 0 {
+		return services.ErrForbidden
+	}
+
+	teams, err := s.repo.ListTeams(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("service ListTeams(): %w", fromRepo(err))
+	}
+	for _, m := range memberships {
+		if slices.ContainsFunc(teams, func(t models.Team) bool { return t.ID == m.TeamID }) {
+			return nil
+		}
+	}
+	return services.ErrForbidden
+}
+
+func isOwner(ctx context.Context, org models.Organization) bool {
+	actor, ok := services.ActorFromContext(ctx)
+	if !ok {
+		return true
+	}
+	return org.OwnerID != nil && *org.OwnerID == actor
+}
+
+func fromRepo(err error) error {
+	return services.FromRepo(err, errOrganizationNotFound, nil)
+}
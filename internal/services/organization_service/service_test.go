@@ -0,0 +1,91 @@
+package organization_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/services/organization_service"
+	"github.com/sunr3d/subscription-aggregator/mocks"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestService_CreateOrganization_OwnerFromActor(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-1")
+	repo := mocks.NewOrganizations(t)
+	svc := organization_service.New(repo)
+
+	repo.EXPECT().CreateOrganization(ctx, models.Organization{Name: "Acme", OwnerID: ptr("u-1")}).Return(1, nil)
+
+	id, err := svc.CreateOrganization(ctx, models.Organization{Name: "Acme"})
+	require.NoError(t, err)
+	require.Equal(t, 1, id)
+}
+
+func TestService_CreateTeam_ErrValidation_NoOrganization(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewOrganizations(t)
+	svc := organization_service.New(repo)
+
+	repo.EXPECT().GetOrganization(ctx, 1).Return(models.Organization{}, infra.ErrNotFound)
+
+	_, err := svc.CreateTeam(ctx, models.Team{OrganizationID: 1, Name: "Core"})
+	var vErr *services.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, "organization_id", vErr.Fields[0].Field)
+}
+
+func TestService_CreateTeam_ErrForbidden_NotOwner(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-2")
+	repo := mocks.NewOrganizations(t)
+	svc := organization_service.New(repo)
+
+	repo.EXPECT().GetOrganization(ctx, 1).Return(models.Organization{ID: 1, OwnerID: ptr("u-1")}, nil)
+
+	_, err := svc.CreateTeam(ctx, models.Team{OrganizationID: 1, Name: "Core"})
+	require.ErrorIs(t, err, services.ErrForbidden)
+}
+
+func TestService_SetMember_TeamAdmin(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-2")
+	repo := mocks.NewOrganizations(t)
+	svc := organization_service.New(repo)
+
+	repo.EXPECT().GetTeam(ctx, 3).Return(models.Team{ID: 3, OrganizationID: 1}, nil)
+	repo.EXPECT().ListMembers(ctx, 3).Return([]models.TeamMember{{TeamID: 3, UserID: "u-2", Role: models.RoleAdmin}}, nil)
+	repo.EXPECT().SetMember(ctx, models.TeamMember{TeamID: 3, UserID: "u-3", Role: models.RoleMember}).Return(nil)
+
+	require.NoError(t, svc.SetMember(ctx, models.TeamMember{TeamID: 3, UserID: "u-3"}))
+}
+
+func TestService_SetMember_ErrForbidden_PlainMember(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-2")
+	repo := mocks.NewOrganizations(t)
+	svc := organization_service.New(repo)
+
+	repo.EXPECT().GetTeam(ctx, 3).Return(models.Team{ID: 3, OrganizationID: 1}, nil)
+	repo.EXPECT().ListMembers(ctx, 3).Return([]models.TeamMember{{TeamID: 3, UserID: "u-2", Role: models.RoleMember}}, nil)
+	repo.EXPECT().GetOrganization(ctx, 1).Return(models.Organization{ID: 1, OwnerID: ptr("u-1")}, nil)
+
+	err := svc.SetMember(ctx, models.TeamMember{TeamID: 3, UserID: "u-3"})
+	require.ErrorIs(t, err, services.ErrForbidden)
+}
+
+func TestService_RemoveMember_ErrNotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewOrganizations(t)
+	svc := organization_service.New(repo)
+
+	repo.EXPECT().GetTeam(ctx, 3).Return(models.Team{ID: 3, OrganizationID: 1}, nil)
+	repo.EXPECT().ListMembers(ctx, 3).Return(nil, nil)
+	repo.EXPECT().RemoveMember(ctx, 3, "u-3").Return(infra.ErrNotFound)
+
+	err := svc.RemoveMember(ctx, 3, "u-3")
+	require.ErrorIs(t, err, services.ErrNotFound)
+}
@@ -0,0 +1,222 @@
+package subscription_service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ services.SubscriptionService = (*authorizedService)(nil)
+
+// authorizedService ограничивает доступ к подпискам пользователем из контекста запроса:
+// доступны его собственные подписки и подписки команд, в которых он состоит.
+// Участник чужой подписки может её читать, но не менять.
+// Без пользователя в контексте (авторизация выключена) запросы передаются как есть.
+// Оборачивает кеш, чтобы права проверялись и для ответов из кеша.
+type authorizedService struct {
+	next services.SubscriptionService
+	orgs infra.Organizations
+}
+
+func WithAuthorization(next services.SubscriptionService, orgs infra.Organizations) services.SubscriptionService {
+	return &authorizedService{next: next, orgs: orgs}
+}
+
+func (a *authorizedService) Create(ctx context.Context, data models.Subscription) (int, error) {
+	v, err := a.visibility(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if !canAccess(v, data) {
+		return -1, services.ErrForbidden
+	}
+	return a.next.Create(ctx, data)
+}
+
+func (a *authorizedService) GetByID(ctx context.Context, id int) (models.Subscription, error) {
+	v, err := a.visibility(ctx)
+	if err != nil {
+		return models.Subscription{}, err
+	}
+	res, err := a.next.GetByID(ctx, id)
+	if err != nil {
+		return res, err
+	}
+	if !canAccess(v, res) && !isParticipant(v, res) {
+		return models.Subscription{}, services.ErrForbidden
+	}
+	return res, nil
+}
+
+func (a *authorizedService) Update(ctx context.Context, data models.Subscription) error {
+	v, err := a.visibility(ctx)
+	if err != nil {
+		return err
+	}
+	if v != nil {
+		// Менять можно только доступную подписку и только так, чтобы она осталась доступной.
+		old, err := a.next.GetByID(ctx, data.ID)
+		if err != nil {
+			return err
+		}
+		if !canAccess(v, old) || !canAccess(v, data) {
+			return services.ErrForbidden
+		}
+	}
+	return a.next.Update(ctx, data)
+}
+
+func (a *authorizedService) Delete(ctx context.Context, id int) error {
+	if err := a.checkManage(ctx, id); err != nil {
+		return err
+	}
+	return a.next.Delete(ctx, id)
+}
+
+func (a *authorizedService) List(ctx context.Context, filter services.ListFilter) ([]models.Subscription, error) {
+	if err := a.restrict(ctx, &filter); err != nil {
+		return nil, err
+	}
+	return a.next.List(ctx, filter)
+}
+
+func (a *authorizedService) TotalCost(ctx context.Context, start, end time.Time, filter services.ListFilter) (int, error) {
+	if err := a.restrict(ctx, &filter); err != nil {
+		return 0, err
+	}
+	return a.next.TotalCost(ctx, start, end, filter)
+}
+
+func (a *authorizedService) TotalCostByTeam(ctx context.Context, start, end time.Time, filter services.ListFilter) ([]services.TeamCost, error) {
+	if err := a.restrict(ctx, &filter); err != nil {
+		return nil, err
+	}
+	return a.next.TotalCostByTeam(ctx, start, end, filter)
+}
+
+func (a *authorizedService) Forecast(ctx context.Context, from time.Time, months int, filter services.ListFilter) ([]services.MonthCost, error) {
+	if err := a.restrict(ctx, &filter); err != nil {
+		return nil, err
+	}
+	return a.next.Forecast(ctx, from, months, filter)
+}
+
+func (a *authorizedService) FindOverlaps(ctx context.Context, data models.Subscription) ([]models.Subscription, error) {
+	v, err := a.visibility(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.next.FindOverlaps(ctx, data)
+	if err != nil || v == nil {
+		return res, err
+	}
+	return slices.DeleteFunc(res, func(item models.Subscription) bool { return !canAccess(v, item) && !isParticipant(v, item) }), nil
+}
+
+func (a *authorizedService) Duplicates(ctx context.Context, filter services.ListFilter) ([]services.DuplicateGroup, error) {
+	if err := a.restrict(ctx, &filter); err != nil {
+		return nil, err
+	}
+	return a.next.Duplicates(ctx, filter)
+}
+
+func (a *authorizedService) SetParticipant(ctx context.Context, id int, data models.Participant) error {
+	if err := a.checkManage(ctx, id); err != nil {
+		return err
+	}
+	return a.next.SetParticipant(ctx, id, data)
+}
+
+func (a *authorizedService) RemoveParticipant(ctx context.Context, id int, userID string) error {
+	if err := a.checkManage(ctx, id); err != nil {
+		return err
+	}
+	return a.next.RemoveParticipant(ctx, id, userID)
+}
+
+func (a *authorizedService) SetPriceChange(ctx context.Context, id int, data models.PriceChange) error {
+	if err := a.checkManage(ctx, id); err != nil {
+		return err
+	}
+	return a.next.SetPriceChange(ctx, id, data)
+}
+
+func (a *authorizedService) RemovePriceChange(ctx context.Context, id int, effectiveFrom time.Time) error {
+	if err := a.checkManage(ctx, id); err != nil {
+		return err
+	}
+	return a.next.RemovePriceChange(ctx, id, effectiveFrom)
+}
+
+func (a *authorizedService) Pause(ctx context.Context, id int, data models.Pause) error {
+	if err := a.checkManage(ctx, id); err != nil {
+		return err
+	}
+	return a.next.Pause(ctx, id, data)
+}
+
+func (a *authorizedService) Resume(ctx context.Context, id int, from time.Time) error {
+	if err := a.checkManage(ctx, id); err != nil {
+		return err
+	}
+	return a.next.Resume(ctx, id, from)
+}
+
+// checkManage - менять подписку и её участников может владелец или участник её команды.
+func (a *authorizedService) checkManage(ctx context.Context, id int) error {
+	v, err := a.visibility(ctx)
+	if err != nil || v == nil {
+		return err
+	}
+	old, err := a.next.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !canAccess(v, old) {
+		return services.ErrForbidden
+	}
+	return nil
+}
+
+func (a *authorizedService) restrict(ctx context.Context, filter *services.ListFilter) error {
+	v, err := a.visibility(ctx)
+	if err != nil {
+		return err
+	}
+	filter.VisibleTo = v
+	return nil
+}
+
+// visibility - пользователь запроса и его команды; nil, если авторизация выключена.
+func (a *authorizedService) visibility(ctx context.Context) (*services.Visibility, error) {
+	actor, ok := services.ActorFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	memberships, err := a.orgs.UserTeams(ctx, actor)
+	if err != nil {
+		return nil, fmt.Errorf("service UserTeams(): %w", err)
+	}
+	v := &services.Visibility{UserID: actor, TeamIDs: make([]int, 0, len(memberships))}
+	for _, m := range memberships {
+		v.TeamIDs = append(v.TeamIDs, m.TeamID)
+	}
+	return v, nil
+}
+
+func isParticipant(v *services.Visibility, data models.Subscription) bool {
+	return v != nil && slices.ContainsFunc(data.Participants, func(p models.Participant) bool { return p.UserID == v.UserID })
+}
+
+func canAccess(v *services.Visibility, data models.Subscription) bool {
+	if v == nil || data.UserID == v.UserID {
+		return true
+	}
+	return data.TeamID != nil && slices.Contains(v.TeamIDs, *data.TeamID)
+}
@@ -0,0 +1,108 @@
+package subscription_service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/services/subscription_service"
+	"github.com/sunr3d/subscription-aggregator/mocks"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func TestAuth_GetByID_OwnAndTeam(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-1")
+	next := mocks.NewSubscriptionService(t)
+	orgs := mocks.NewOrganizations(t)
+	svc := subscription_service.WithAuthorization(next, orgs)
+
+	teamID := 7
+	orgs.EXPECT().UserTeams(ctx, "u-1").Return([]models.TeamMember{{TeamID: teamID, UserID: "u-1", Role: models.RoleMember}}, nil)
+	next.EXPECT().GetByID(ctx, 1).Return(models.Subscription{ID: 1, UserID: "u-1"}, nil)
+	next.EXPECT().GetByID(ctx, 2).Return(models.Subscription{ID: 2, UserID: "u-2", TeamID: &teamID}, nil)
+
+	for _, id := range []int{1, 2} {
+		res, err := svc.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, id, res.ID)
+	}
+}
+
+func TestAuth_GetByID_ErrForbidden(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-1")
+	next := mocks.NewSubscriptionService(t)
+	orgs := mocks.NewOrganizations(t)
+	svc := subscription_service.WithAuthorization(next, orgs)
+
+	otherTeam := 8
+	orgs.EXPECT().UserTeams(ctx, "u-1").Return(nil, nil)
+	next.EXPECT().GetByID(ctx, 1).Return(models.Subscription{ID: 1, UserID: "u-2", TeamID: &otherTeam}, nil)
+
+	_, err := svc.GetByID(ctx, 1)
+	require.ErrorIs(t, err, services.ErrForbidden)
+}
+
+func TestAuth_Update_ErrForbidden_MoveToForeignTeam(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-1")
+	next := mocks.NewSubscriptionService(t)
+	orgs := mocks.NewOrganizations(t)
+	svc := subscription_service.WithAuthorization(next, orgs)
+
+	otherTeam := 8
+	orgs.EXPECT().UserTeams(ctx, "u-1").Return(nil, nil)
+	next.EXPECT().GetByID(ctx, 1).Return(models.Subscription{ID: 1, UserID: "u-1"}, nil)
+
+	err := svc.Update(ctx, models.Subscription{ID: 1, UserID: "u-2", TeamID: &otherTeam})
+	require.ErrorIs(t, err, services.ErrForbidden)
+}
+
+func TestAuth_List_RestrictsToVisible(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-1")
+	next := mocks.NewSubscriptionService(t)
+	orgs := mocks.NewOrganizations(t)
+	svc := subscription_service.WithAuthorization(next, orgs)
+
+	orgs.EXPECT().UserTeams(ctx, "u-1").Return([]models.TeamMember{{TeamID: 3, UserID: "u-1"}, {TeamID: 5, UserID: "u-1"}}, nil)
+	next.EXPECT().List(ctx, mock.MatchedBy(func(f services.ListFilter) bool {
+		return f.VisibleTo != nil && f.VisibleTo.UserID == "u-1" && len(f.VisibleTo.TeamIDs) == 2
+	})).Return(nil, nil)
+
+	_, err := svc.List(ctx, services.ListFilter{})
+	require.NoError(t, err)
+}
+
+func TestAuth_Disabled_PassThrough(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	orgs := mocks.NewOrganizations(t)
+	svc := subscription_service.WithAuthorization(next, orgs)
+
+	next.EXPECT().List(ctx, services.ListFilter{}).Return(nil, nil)
+	next.EXPECT().GetByID(ctx, 1).Return(models.Subscription{ID: 1, UserID: "u-2"}, nil)
+
+	_, err := svc.List(ctx, services.ListFilter{})
+	require.NoError(t, err)
+	_, err = svc.GetByID(ctx, 1)
+	require.NoError(t, err)
+}
+
+func TestAuth_Participant_CanReadButNotManage(t *testing.T) {
+	ctx := services.WithActor(context.Background(), "u-2")
+	next := mocks.NewSubscriptionService(t)
+	orgs := mocks.NewOrganizations(t)
+	svc := subscription_service.WithAuthorization(next, orgs)
+
+	weight := 1
+	sub := models.Subscription{ID: 1, UserID: "u-1", Participants: []models.Participant{{UserID: "u-2", ShareWeight: &weight}}}
+	orgs.EXPECT().UserTeams(ctx, "u-2").Return(nil, nil)
+	next.EXPECT().GetByID(ctx, 1).Return(sub, nil)
+
+	_, err := svc.GetByID(ctx, 1)
+	require.NoError(t, err)
+
+	err = svc.RemoveParticipant(ctx, 1, "u-2")
+	require.ErrorIs(t, err, services.ErrForbidden)
+}
@@ -0,0 +1,384 @@
+package subscription_service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/metrics"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ services.SubscriptionService = (*cachedService)(nil)
+
+const (
+	cachePrefix  = "subscriptions:"
+	genTTLFactor = 10
+)
+
+// cachedService кеширует GetByID и TotalCost.
+//
+// Ключи включают поколения областей: подписки для GetByID, user_id, service_name
+// или всех подписок для TotalCost. Запись подписки меняет поколения её id, владельца, участников
+// и сервиса (старых и новых), и устаревшие значения больше не читаются, а вытесняются по LRU/TTL.
+// Значение, посчитанное параллельно с записью, сохраняется под старым поколением и тоже не читается. Поколения хранятся в том же кеше, поэтому инвалидация работает
+// и с внешним кешем, общим для нескольких экземпляров сервиса.
+type cachedService struct {
+	next  services.SubscriptionService
+	cache infra.Cache
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+func WithCache(next services.SubscriptionService, cache infra.Cache, ttl time.Duration) services.SubscriptionService {
+	return &cachedService{next: next, cache: cache, ttl: ttl, now: time.Now}
+}
+
+func (c *cachedService) Create(ctx context.Context, data models.Subscription) (int, error) {
+	id, err := c.next.Create(ctx, data)
+	c.invalidate(ctx, 0, data)
+	return id, err
+}
+
+func (c *cachedService) GetByID(ctx context.Context, id int) (models.Subscription, error) {
+	key := cachePrefix + "id:" + strconv.Itoa(id) + ":" + c.generation(ctx, idScope(id))
+
+	var res models.Subscription
+	if c.load(ctx, "get_by_id", key, &res) {
+		return res, nil
+	}
+
+	res, err := c.next.GetByID(ctx, id)
+	if err != nil {
+		return res, err
+	}
+	c.store(ctx, key, res)
+	return res, nil
+}
+
+func (c *cachedService) Update(ctx context.Context, data models.Subscription) error {
+	// Старые user_id и service_name нужны, чтобы сбросить суммы, из которых подписка уходит.
+	old, _ := c.next.GetByID(ctx, data.ID)
+	err := c.next.Update(ctx, data)
+	c.invalidate(ctx, data.ID, old, data)
+	return err
+}
+
+func (c *cachedService) Delete(ctx context.Context, id int) error {
+	old, _ := c.next.GetByID(ctx, id)
+	err := c.next.Delete(ctx, id)
+	c.invalidate(ctx, id, old)
+	return err
+}
+
+func (c *cachedService) List(ctx context.Context, filter services.ListFilter) ([]models.Subscription, error) {
+	return c.next.List(ctx, filter)
+}
+
+func (c *cachedService) TotalCost(ctx context.Context, start, end time.Time, filter services.ListFilter) (int, error) {
+	key := c.totalKey(ctx, "total", start, end, filter)
+
+	var sum int
+	if c.load(ctx, "total_cost", key, &sum) {
+		return sum, nil
+	}
+
+	sum, err := c.next.TotalCost(ctx, start, end, filter)
+	if err != nil {
+		return sum, err
+	}
+	c.store(ctx, key, sum)
+	return sum, nil
+}
+
+func (c *cachedService) TotalCostByTeam(ctx context.Context, start, end time.Time, filter services.ListFilter) ([]services.TeamCost, error) {
+	key := c.totalKey(ctx, "total_by_team", start, end, filter)
+
+	var res []services.TeamCost
+	if c.load(ctx, "total_cost_by_team", key, &res) {
+		return res, nil
+	}
+
+	res, err := c.next.TotalCostByTeam(ctx, start, end, filter)
+	if err != nil {
+		return res, err
+	}
+	c.store(ctx, key, res)
+	return res, nil
+}
+
+// totalKey - ключ суммы за период. В него входят поколения областей, покрывающих фильтр:
+// любая подписка из результата принадлежит одному из перечисленных пользователей
+// (сервисов, команд), и её изменение меняет поколение. Без таких фильтров - поколение всех подписок.
+func (c *cachedService) totalKey(ctx context.Context, kind string, start, end time.Time, filter services.ListFilter) string {
+	var scopes []string
+	for _, userID := range filter.UserIDs {
+		scopes = append(scopes, userScope(userID))
+	}
+	for _, serviceName := range filter.ServiceNames {
+		scopes = append(scopes, serviceScope(serviceName))
+	}
+	for _, teamID := range filter.TeamIDs {
+		scopes = append(scopes, teamScope(teamID))
+	}
+	if v := filter.VisibleTo; v != nil {
+		scopes = append(scopes, userScope(v.UserID))
+		for _, teamID := range v.TeamIDs {
+			scopes = append(scopes, teamScope(teamID))
+		}
+	}
+	if len(scopes) == 0 {
+		scopes = append(scopes, allScope)
+	}
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+
+	gens := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		gens = append(gens, c.generation(ctx, scope))
+	}
+
+	return cachePrefix + kind + ":" + strings.Join([]string{
+		normalizeDate(start).Format("2006-01"),
+		normalizeDate(end).Format("2006-01"),
+		filterKey(filter),
+		strings.Join(gens, ","),
+	}, ":")
+}
+
+func (c *cachedService) Forecast(ctx context.Context, from time.Time, months int, filter services.ListFilter) ([]services.MonthCost, error) {
+	// Месяц по умолчанию выбирается до построения ключа, иначе нулевой from давал бы один ключ во все месяцы.
+	if from.IsZero() {
+		from = normalizeDate(c.now())
+	}
+	key := c.totalKey(ctx, "forecast", from, normalizeDate(from).AddDate(0, months-1, 0), filter)
+
+	var res []services.MonthCost
+	if c.load(ctx, "forecast", key, &res) {
+		return res, nil
+	}
+
+	res, err := c.next.Forecast(ctx, from, months, filter)
+	if err != nil {
+		return res, err
+	}
+	c.store(ctx, key, res)
+	return res, nil
+}
+
+func (c *cachedService) FindOverlaps(ctx context.Context, data models.Subscription) ([]models.Subscription, error) {
+	return c.next.FindOverlaps(ctx, data)
+}
+
+func (c *cachedService) Duplicates(ctx context.Context, filter services.ListFilter) ([]services.DuplicateGroup, error) {
+	return c.next.Duplicates(ctx, filter)
+}
+
+// SetParticipant и RemoveParticipant меняют доли всех участников и владельца, поэтому
+// сбрасываются суммы старого состава и нового участника.
+func (c *cachedService) SetParticipant(ctx context.Context, id int, data models.Participant) error {
+	old, _ := c.next.GetByID(ctx, id)
+	err := c.next.SetParticipant(ctx, id, data)
+	c.invalidate(ctx, id, old, models.Subscription{UserID: data.UserID})
+	return err
+}
+
+func (c *cachedService) RemoveParticipant(ctx context.Context, id int, userID string) error {
+	old, _ := c.next.GetByID(ctx, id)
+	err := c.next.RemoveParticipant(ctx, id, userID)
+	c.invalidate(ctx, id, old, models.Subscription{UserID: userID})
+	return err
+}
+
+// SetPriceChange и RemovePriceChange меняют суммы всех плательщиков подписки.
+func (c *cachedService) SetPriceChange(ctx context.Context, id int, data models.PriceChange) error {
+	old, _ := c.next.GetByID(ctx, id)
+	err := c.next.SetPriceChange(ctx, id, data)
+	c.invalidate(ctx, id, old)
+	return err
+}
+
+func (c *cachedService) RemovePriceChange(ctx context.Context, id int, effectiveFrom time.Time) error {
+	old, _ := c.next.GetByID(ctx, id)
+	err := c.next.RemovePriceChange(ctx, id, effectiveFrom)
+	c.invalidate(ctx, id, old)
+	return err
+}
+
+// Pause и Resume меняют оплачиваемые месяцы подписки, а значит, суммы всех её плательщиков.
+func (c *cachedService) Pause(ctx context.Context, id int, data models.Pause) error {
+	old, _ := c.next.GetByID(ctx, id)
+	err := c.next.Pause(ctx, id, data)
+	c.invalidate(ctx, id, old)
+	return err
+}
+
+func (c *cachedService) Resume(ctx context.Context, id int, from time.Time) error {
+	old, _ := c.next.GetByID(ctx, id)
+	err := c.next.Resume(ctx, id, from)
+	c.invalidate(ctx, id, old)
+	return err
+}
+
+// load читает значение из кеша. Запросы, требующие чтения с primary, кеш не читают:
+// им нужны данные, актуальные на момент запроса.
+func (c *cachedService) load(ctx context.Context, operation, key string, dst any) bool {
+	if infra.PrimaryReadsFromContext(ctx) {
+		metrics.CacheRequests.WithLabelValues(operation, "bypass").Inc()
+		return false
+	}
+
+	raw, ok, err := c.cache.Get(ctx, key)
+	if err == nil && ok && json.Unmarshal(raw, dst) == nil {
+		metrics.CacheRequests.WithLabelValues(operation, "hit").Inc()
+		return true
+	}
+	metrics.CacheRequests.WithLabelValues(operation, "miss").Inc()
+	return false
+}
+
+func (c *cachedService) store(ctx context.Context, key string, v any) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	_ = c.cache.Set(ctx, key, raw, c.ttl)
+}
+
+const allScope = "all"
+
+func idScope(id int) string {
+	return "id:" + strconv.Itoa(id)
+}
+
+func userScope(userID string) string {
+	return "user:" + strconv.Quote(userID)
+}
+
+func serviceScope(serviceName string) string {
+	return "service:" + strconv.Quote(serviceName)
+}
+
+func teamScope(teamID int) string {
+	return "team:" + strconv.Itoa(teamID)
+}
+
+// generation возвращает текущее поколение области. Отсутствующее (в том числе вытесненное)
+// поколение создаётся заново, чтобы суммы, посчитанные до вытеснения, не стали снова видны.
+func (c *cachedService) generation(ctx context.Context, scope string) string {
+	key := cachePrefix + "gen:" + scope
+	if raw, ok, err := c.cache.Get(ctx, key); err == nil && ok {
+		return string(raw)
+	}
+	return c.bump(ctx, scope)
+}
+
+func (c *cachedService) bump(ctx context.Context, scope string) string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	gen := hex.EncodeToString(b)
+	// Поколение живёт дольше значений: его истечение безопасно, но сбрасывает всю область.
+	_ = c.cache.Set(ctx, cachePrefix+"gen:"+scope, []byte(gen), genTTLFactor*c.ttl)
+	return gen
+}
+
+// invalidate сбрасывает GetByID для id (если он известен) и суммы, в которые входят subs.
+func (c *cachedService) invalidate(ctx context.Context, id int, subs ...models.Subscription) {
+	if id > 0 {
+		c.bump(ctx, idScope(id))
+	}
+	c.bump(ctx, allScope)
+	for _, data := range subs {
+		if data.UserID != "" {
+			c.bump(ctx, userScope(data.UserID))
+		}
+		for _, p := range data.Participants {
+			c.bump(ctx, userScope(p.UserID))
+		}
+		if data.ServiceName != "" {
+			c.bump(ctx, serviceScope(data.ServiceName))
+		}
+		if data.TeamID != nil {
+			c.bump(ctx, teamScope(*data.TeamID))
+		}
+	}
+}
+
+// filterKey - часть ключа суммы, однозначно задающая фильтр.
+// Статус зависит от текущего месяца, поэтому месяц входит в ключ.
+func filterKey(filter services.ListFilter) string {
+	optInt := func(v int, ok bool) string {
+		if !ok {
+			return "-"
+		}
+		return strconv.Itoa(v)
+	}
+	optTime := func(v time.Time, ok bool, layout string) string {
+		if !ok {
+			return "-"
+		}
+		return v.UTC().Format(layout)
+	}
+
+	status := string(filter.Status)
+	if status != "" {
+		status += "@" + normalizeDate(time.Now()).Format("2006-01")
+	}
+	trial := "-"
+	if filter.HasTrialEndsWithin {
+		trial = strconv.Itoa(filter.TrialEndsWithin) + "@" + normalizeDate(time.Now()).Format("2006-01")
+	}
+
+	return strings.Join([]string{
+		quoteAll(filter.UserIDs),
+		strconv.FormatBool(filter.IncludeShared),
+		quoteAll(filter.ServiceNames),
+		quoteAll(filter.Categories),
+		joinInts(filter.TeamIDs),
+		joinInts(filter.OrganizationIDs),
+		visibilityKey(filter.VisibleTo),
+		strconv.Quote(filter.Query),
+		optTime(filter.CreatedAfter, filter.HasCreatedAfter, time.RFC3339Nano),
+		optTime(filter.UpdatedAfter, filter.HasUpdatedAfter, time.RFC3339Nano),
+		optInt(filter.PriceMin, filter.HasPriceMin),
+		optInt(filter.PriceMax, filter.HasPriceMax),
+		optTime(filter.StartFrom, filter.HasStartFrom, "2006-01"),
+		optTime(filter.StartTo, filter.HasStartTo, "2006-01"),
+		optTime(filter.ActiveAt, filter.HasActiveAt, "2006-01"),
+		status,
+		trial,
+	}, "|")
+}
+
+// quoteAll - множество значений фильтра в ключе, не зависящее от их порядка.
+func quoteAll(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	slices.Sort(quoted)
+	return strings.Join(quoted, ",")
+}
+
+func joinInts(values []int) string {
+	sorted := slices.Sorted(slices.Values(values))
+	parts := make([]string, 0, len(sorted))
+	for _, v := range sorted {
+		parts = append(parts, strconv.Itoa(v))
+	}
+	return strings.Join(parts, ",")
+}
+
+func visibilityKey(v *services.Visibility) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Quote(v.UserID) + "/" + joinInts(v.TeamIDs)
+}
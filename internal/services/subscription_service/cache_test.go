@@ -0,0 +1,252 @@
+package subscription_service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/internal/infra/lru"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/services/subscription_service"
+	"github.com/sunr3d/subscription-aggregator/mocks"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func TestCache_TotalCost_HitAndInvalidateOnCreate(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	filter := services.ListFilter{UserIDs: []string{"u-1"}}
+	start, end := ym(2025, time.January), ym(2025, time.December)
+
+	next.EXPECT().TotalCost(ctx, start, end, filter).Return(400, nil).Once()
+
+	for range 2 {
+		sum, err := svc.TotalCost(ctx, start, end, filter)
+		require.NoError(t, err)
+		require.Equal(t, 400, sum)
+	}
+
+	sub := models.Subscription{ServiceName: "Yandex Plus", Price: 100, UserID: "u-1", StartDate: ym(2025, time.July)}
+	next.EXPECT().Create(ctx, sub).Return(2, nil)
+	_, err := svc.Create(ctx, sub)
+	require.NoError(t, err)
+
+	next.EXPECT().TotalCost(ctx, start, end, filter).Return(1000, nil).Once()
+	sum, err := svc.TotalCost(ctx, start, end, filter)
+	require.NoError(t, err)
+	require.Equal(t, 1000, sum)
+}
+
+func TestCache_TotalCost_OtherUserKeepsCache(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	filter := services.ListFilter{UserIDs: []string{"u-1"}}
+	start, end := ym(2025, time.January), ym(2025, time.December)
+
+	next.EXPECT().TotalCost(ctx, start, end, filter).Return(400, nil).Once()
+	_, err := svc.TotalCost(ctx, start, end, filter)
+	require.NoError(t, err)
+
+	sub := models.Subscription{ServiceName: "Okko", Price: 100, UserID: "u-2", StartDate: ym(2025, time.July)}
+	next.EXPECT().Create(ctx, sub).Return(2, nil)
+	_, err = svc.Create(ctx, sub)
+	require.NoError(t, err)
+
+	sum, err := svc.TotalCost(ctx, start, end, filter)
+	require.NoError(t, err)
+	require.Equal(t, 400, sum)
+}
+
+func TestCache_TotalCost_FiltersInKey(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	start, end := ym(2025, time.January), ym(2025, time.December)
+	cheap := services.ListFilter{PriceMax: 300, HasPriceMax: true}
+	active := services.ListFilter{Status: services.StatusActive}
+
+	next.EXPECT().TotalCost(ctx, start, end, cheap).Return(300, nil).Once()
+	next.EXPECT().TotalCost(ctx, start, end, active).Return(900, nil).Once()
+
+	sum, err := svc.TotalCost(ctx, start, end, cheap)
+	require.NoError(t, err)
+	require.Equal(t, 300, sum)
+
+	sum, err = svc.TotalCost(ctx, start, end, active)
+	require.NoError(t, err)
+	require.Equal(t, 900, sum)
+}
+
+func TestCache_TotalCost_MultiUserInvalidatedByAnyUser(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	filter := services.ListFilter{UserIDs: []string{"u-1", "u-2"}}
+	reordered := services.ListFilter{UserIDs: []string{"u-2", "u-1"}}
+	start, end := ym(2025, time.January), ym(2025, time.December)
+
+	next.EXPECT().TotalCost(ctx, start, end, filter).Return(400, nil).Once()
+	_, err := svc.TotalCost(ctx, start, end, filter)
+	require.NoError(t, err)
+
+	sum, err := svc.TotalCost(ctx, start, end, reordered)
+	require.NoError(t, err)
+	require.Equal(t, 400, sum)
+
+	sub := models.Subscription{ServiceName: "Okko", Price: 100, UserID: "u-2", StartDate: ym(2025, time.July)}
+	next.EXPECT().Create(ctx, sub).Return(2, nil)
+	_, err = svc.Create(ctx, sub)
+	require.NoError(t, err)
+
+	next.EXPECT().TotalCost(ctx, start, end, filter).Return(1600, nil).Once()
+	sum, err = svc.TotalCost(ctx, start, end, filter)
+	require.NoError(t, err)
+	require.Equal(t, 1600, sum)
+}
+
+func TestCache_Update_InvalidatesOldAndNewScopes(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	oldSub := models.Subscription{ID: 1, ServiceName: "Okko", Price: 100, UserID: "u-1", StartDate: ym(2025, time.July)}
+	newSub := oldSub
+	newSub.ServiceName = "Yandex Plus"
+
+	okko := services.ListFilter{ServiceNames: []string{"Okko"}}
+	start, end := ym(2025, time.January), ym(2025, time.December)
+
+	next.EXPECT().GetByID(ctx, 1).Return(oldSub, nil).Once()
+	res, err := svc.GetByID(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, oldSub, res)
+
+	next.EXPECT().TotalCost(ctx, start, end, okko).Return(600, nil).Once()
+	_, err = svc.TotalCost(ctx, start, end, okko)
+	require.NoError(t, err)
+
+	next.EXPECT().GetByID(ctx, 1).Return(oldSub, nil).Once()
+	next.EXPECT().Update(ctx, newSub).Return(nil)
+	require.NoError(t, svc.Update(ctx, newSub))
+
+	next.EXPECT().GetByID(ctx, 1).Return(newSub, nil).Once()
+	res, err = svc.GetByID(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, newSub, res)
+
+	next.EXPECT().TotalCost(ctx, start, end, okko).Return(0, nil).Once()
+	sum, err := svc.TotalCost(ctx, start, end, okko)
+	require.NoError(t, err)
+	require.Equal(t, 0, sum)
+}
+
+func TestCache_GetByID_BypassOnPrimaryReads(t *testing.T) {
+	ctx := infra.WithPrimaryReads(context.Background())
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	sub := models.Subscription{ID: 1, ServiceName: "Okko", UserID: "u-1", StartDate: ym(2025, time.July)}
+	next.EXPECT().GetByID(mock.Anything, 1).Return(sub, nil).Twice()
+
+	for range 2 {
+		_, err := svc.GetByID(ctx, 1)
+		require.NoError(t, err)
+	}
+}
+
+func TestCache_GetByID_ErrorNotCached(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	next.EXPECT().GetByID(ctx, 1).Return(models.Subscription{}, services.ErrNotFound).Twice()
+
+	for range 2 {
+		_, err := svc.GetByID(ctx, 1)
+		require.ErrorIs(t, err, services.ErrNotFound)
+	}
+}
+
+func TestCache_TotalCost_InvalidatedForParticipants(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	filter := services.ListFilter{UserIDs: []string{"u-2"}}
+	start, end := ym(2025, time.January), ym(2025, time.December)
+
+	next.EXPECT().TotalCost(ctx, start, end, filter).Return(100, nil).Once()
+	_, err := svc.TotalCost(ctx, start, end, filter)
+	require.NoError(t, err)
+
+	// Цена подписки владельца u-1 меняет долю участника u-2.
+	weight := 1
+	old := models.Subscription{ID: 1, ServiceName: "Okko", Price: 200, UserID: "u-1", StartDate: ym(2025, time.January),
+		Participants: []models.Participant{{UserID: "u-1", ShareWeight: &weight}, {UserID: "u-2", ShareWeight: &weight}}}
+	upd := old
+	upd.Price = 400
+	next.EXPECT().GetByID(ctx, 1).Return(old, nil)
+	next.EXPECT().Update(ctx, upd).Return(nil)
+	require.NoError(t, svc.Update(ctx, upd))
+
+	next.EXPECT().TotalCost(ctx, start, end, filter).Return(200, nil).Once()
+	sum, err := svc.TotalCost(ctx, start, end, filter)
+	require.NoError(t, err)
+	require.Equal(t, 200, sum)
+}
+
+func TestCache_TotalCost_InvalidatedOnSetParticipant(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	filter := services.ListFilter{UserIDs: []string{"u-3"}}
+	start, end := ym(2025, time.January), ym(2025, time.December)
+
+	next.EXPECT().TotalCost(ctx, start, end, filter).Return(0, nil).Once()
+	_, err := svc.TotalCost(ctx, start, end, filter)
+	require.NoError(t, err)
+
+	weight := 1
+	p := models.Participant{UserID: "u-3", ShareWeight: &weight}
+	next.EXPECT().GetByID(ctx, 1).Return(models.Subscription{ID: 1, ServiceName: "Okko", UserID: "u-1"}, nil)
+	next.EXPECT().SetParticipant(ctx, 1, p).Return(nil)
+	require.NoError(t, svc.SetParticipant(ctx, 1, p))
+
+	next.EXPECT().TotalCost(ctx, start, end, filter).Return(300, nil).Once()
+	sum, err := svc.TotalCost(ctx, start, end, filter)
+	require.NoError(t, err)
+	require.Equal(t, 300, sum)
+}
+
+func TestCache_Forecast_DefaultFromKeyedByCurrentMonth(t *testing.T) {
+	ctx := context.Background()
+	next := mocks.NewSubscriptionService(t)
+	svc := subscription_service.WithCache(next, lru.New(100), time.Minute)
+
+	now := time.Now()
+	month := ym(now.Year(), now.Month())
+	filter := services.ListFilter{UserIDs: []string{"u-1"}}
+	res := []services.MonthCost{{Month: month, TotalCost: 400}}
+
+	// Нулевой from уходит в сервис уже текущим месяцем и делит запись кеша с явным from.
+	next.EXPECT().Forecast(ctx, month, 3, filter).Return(res, nil).Once()
+
+	for _, from := range []time.Time{{}, month} {
+		got, err := svc.Forecast(ctx, from, 3, filter)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.True(t, month.Equal(got[0].Month))
+		require.Equal(t, 400, got[0].TotalCost)
+	}
+}
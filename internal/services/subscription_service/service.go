@@ -4,31 +4,133 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"time"
 
+	"github.com/sunr3d/subscription-aggregator/internal/i18n"
 	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
 	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/metrics"
+	"github.com/sunr3d/subscription-aggregator/internal/search"
 	"github.com/sunr3d/subscription-aggregator/models"
 )
 
 var _ services.SubscriptionService = (*subscriptionService)(nil)
 
+var (
+	errNegativePrice = services.FieldError{
+		Field: "price",
+		Code:  services.CodeNegative,
+		Key:   i18n.MsgFieldNegative,
+		Args:  []any{"price"},
+	}
+	errEndBeforeStart = services.FieldError{
+		Field: "end_date",
+		Code:  services.CodeBeforeStart,
+		Key:   i18n.MsgFieldBeforeStart,
+		Args:  []any{"end_date", "start_date"},
+	}
+	errPeriodEndBeforeStart = services.FieldError{
+		Field: "period_end",
+		Code:  services.CodeBeforeStart,
+		Key:   i18n.MsgFieldBeforeStart,
+		Args:  []any{"period_end", "period_start"},
+	}
+	errTeamNotFound = services.FieldError{
+		Field: "team_id",
+		Code:  services.CodeInvalidValue,
+		Key:   i18n.MsgFieldTeamID,
+	}
+	errParticipantShare = services.FieldError{
+		Field: "share_weight",
+		Code:  services.CodeInvalidValue,
+		Key:   i18n.MsgParticipantShare,
+	}
+	errShareWeight = services.FieldError{
+		Field: "share_weight",
+		Code:  services.CodeOutOfRange,
+		Key:   i18n.MsgFieldID,
+		Args:  []any{"share_weight"},
+	}
+	errPriceChangeBeforeStart = services.FieldError{
+		Field: "effective_from",
+		Code:  services.CodeBeforeStart,
+		Key:   i18n.MsgFieldBeforeStart,
+		Args:  []any{"effective_from", "start_date"},
+	}
+	errPriceChangePrice = services.FieldError{
+		Field: "price",
+		Code:  services.CodeNegative,
+		Key:   i18n.MsgFieldNegative,
+		Args:  []any{"price"},
+	}
+	errForecastMonths = services.FieldError{
+		Field: "months",
+		Code:  services.CodeOutOfRange,
+		Key:   i18n.MsgForecastMonths,
+		Args:  []any{services.MaxForecastMonths},
+	}
+	errTrialBeforeStart = services.FieldError{
+		Field: "trial_end",
+		Code:  services.CodeBeforeStart,
+		Key:   i18n.MsgFieldBeforeStart,
+		Args:  []any{"trial_end", "start_date"},
+	}
+	errPauseBeforeStart = services.FieldError{
+		Field: "from",
+		Code:  services.CodeBeforeStart,
+		Key:   i18n.MsgFieldBeforeStart,
+		Args:  []any{"from", "start_date"},
+	}
+	errPauseAfterEnd = services.FieldError{
+		Field: "from",
+		Code:  services.CodeInvalidValue,
+		Key:   i18n.MsgFieldAfterEnd,
+		Args:  []any{"from", "end_date"},
+	}
+	errPauseUntil = services.FieldError{
+		Field: "until",
+		Code:  services.CodeBeforeStart,
+		Key:   i18n.MsgFieldBeforeStart,
+		Args:  []any{"until", "from"},
+	}
+	errPauseOverlap = services.FieldError{
+		Field: "from",
+		Code:  services.CodeInvalidValue,
+		Key:   i18n.MsgPauseOverlap,
+	}
+	errFixedAmount = services.FieldError{
+		Field: "fixed_amount",
+		Code:  services.CodeNegative,
+		Key:   i18n.MsgFieldNegative,
+		Args:  []any{"fixed_amount"},
+	}
+)
+
 type subscriptionService struct {
 	repo infra.Database
+	now  func() time.Time
 }
 
 func New(repo infra.Database) services.SubscriptionService {
-	return &subscriptionService{repo: repo}
+	return &subscriptionService{repo: repo, now: time.Now}
 }
 
 func (s *subscriptionService) Create(ctx context.Context, data models.Subscription) (int, error) {
-	if data.Price < 0 {
-		return -1, fmt.Errorf("%w: price не может быть отрицательным", services.ErrValidation)
+	if err := validate(data); err != nil {
+		return -1, err
 	}
-	if data.EndDate != nil && data.EndDate.Before(data.StartDate) {
-		return -1, fmt.Errorf("%w: end_date не может быть раньше start_date", services.ErrValidation)
+	if err := s.checkOverlaps(ctx, data); err != nil {
+		return -1, err
+	}
+
+	id, err := s.repo.Create(ctx, data)
+	if err != nil {
+		return -1, fmt.Errorf("service Create(): %w", fromRepo(err))
 	}
-	return s.repo.Create(ctx, data)
+	metrics.SubscriptionsCreated.Inc()
+	return id, nil
 }
 
 func (s *subscriptionService) GetByID(ctx context.Context, id int) (models.Subscription, error) {
@@ -37,23 +139,23 @@ func (s *subscriptionService) GetByID(ctx context.Context, id int) (models.Subsc
 		if errors.Is(err, infra.ErrNotFound) {
 			return models.Subscription{}, services.ErrNotFound
 		}
-		return models.Subscription{}, fmt.Errorf("service GetByID(): %w", err)
+		return models.Subscription{}, fmt.Errorf("service GetByID(): %w", fromRepo(err))
 	}
 	return res, nil
 }
 
 func (s *subscriptionService) Update(ctx context.Context, data models.Subscription) error {
-	if data.Price < 0 {
-		return fmt.Errorf("%w: price не может быть отрицательным", services.ErrValidation)
+	if err := validate(data); err != nil {
+		return err
 	}
-	if data.EndDate != nil && data.EndDate.Before(data.StartDate) {
-		return fmt.Errorf("%w: end_date не может быть раньше start_date", services.ErrValidation)
+	if err := s.checkOverlaps(ctx, data); err != nil {
+		return err
 	}
 	if err := s.repo.Update(ctx, data); err != nil {
 		if errors.Is(err, infra.ErrNotFound) {
 			return services.ErrNotFound
 		}
-		return fmt.Errorf("service Update(): %w", err)
+		return fmt.Errorf("service Update(): %w", fromRepo(err))
 	}
 	return nil
 }
@@ -63,41 +165,154 @@ func (s *subscriptionService) Delete(ctx context.Context, id int) error {
 		if errors.Is(err, infra.ErrNotFound) {
 			return services.ErrNotFound
 		}
-		return fmt.Errorf("service Delete(): %w", err)
+		return fmt.Errorf("service Delete(): %w", fromRepo(err))
 	}
 	return nil
 }
 
 func (s *subscriptionService) List(ctx context.Context, filter services.ListFilter) ([]models.Subscription, error) {
-	var uid, sname *string
-	if filter.HasUserID {
-		uid = &filter.UserID
+	var createdAfter, updatedAfter *time.Time
+	if filter.HasCreatedAfter {
+		createdAfter = &filter.CreatedAfter
+	}
+	if filter.HasUpdatedAfter {
+		updatedAfter = &filter.UpdatedAfter
+	}
+	var priceMin, priceMax *int
+	if filter.HasPriceMin {
+		priceMin = &filter.PriceMin
+	}
+	if filter.HasPriceMax {
+		priceMax = &filter.PriceMax
+	}
+	var startFrom, startTo, activeAt *time.Time
+	if filter.HasStartFrom {
+		t := normalizeDate(filter.StartFrom)
+		startFrom = &t
+	}
+	if filter.HasStartTo {
+		t := normalizeDate(filter.StartTo)
+		startTo = &t
+	}
+	if filter.HasActiveAt {
+		t := normalizeDate(filter.ActiveAt)
+		activeAt = &t
+	}
+	var statusAt time.Time
+	if filter.Status != "" {
+		statusAt = normalizeDate(s.now())
+	}
+	var trialEndFrom, trialEndTo *time.Time
+	if filter.HasTrialEndsWithin {
+		from := normalizeDate(s.now())
+		to := from.AddDate(0, filter.TrialEndsWithin, 0)
+		trialEndFrom, trialEndTo = &from, &to
 	}
-	if filter.HasServiceName {
-		sname = &filter.ServiceName
+	var sortKeys []infra.SortKey
+	for _, key := range filter.Sort {
+		sortKeys = append(sortKeys, infra.SortKey{Column: key.Field, Desc: key.Desc})
 	}
 
-	return s.repo.List(ctx, infra.ListFilter{
-		UserID:      uid,
-		ServiceName: sname,
-		Limit:       filter.Limit,
-		Offset:      filter.Offset,
-	})
+	repoFilter := infra.ListFilter{
+		UserIDs:         filter.UserIDs,
+		IncludeShared:   filter.IncludeShared,
+		ServiceNames:    filter.ServiceNames,
+		Categories:      filter.Categories,
+		TeamIDs:         filter.TeamIDs,
+		OrganizationIDs: filter.OrganizationIDs,
+		CreatedAfter:    createdAfter,
+		UpdatedAfter:    updatedAfter,
+		PriceMin:        priceMin,
+		PriceMax:        priceMax,
+		StartFrom:       startFrom,
+		StartTo:         startTo,
+		ActiveAt:        activeAt,
+		TrialEndFrom:    trialEndFrom,
+		TrialEndTo:      trialEndTo,
+		Status:          string(filter.Status),
+		StatusAt:        statusAt,
+		Sort:            sortKeys,
+		Limit:           filter.Limit,
+		Offset:          filter.Offset,
+	}
+	if filter.VisibleTo != nil {
+		repoFilter.VisibleTo = &infra.Visibility{UserID: filter.VisibleTo.UserID, TeamIDs: filter.VisibleTo.TeamIDs}
+	}
+	if filter.HasQuery {
+		repoFilter.Query = &filter.Query
+		if !supportsTextSearch(s.repo) {
+			return s.searchInMemory(ctx, repoFilter)
+		}
+	}
+
+	data, err := s.repo.List(ctx, repoFilter)
+	if err != nil {
+		return nil, fmt.Errorf("service List(): %w", fromRepo(err))
+	}
+	return data, nil
 }
 
-func (s *subscriptionService) TotalCost(ctx context.Context, periodStart, periodEnd time.Time, filter services.ListFilter) (int, error) {
-	normalizeDate := func(t time.Time) time.Time {
-		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+func supportsTextSearch(repo infra.Database) bool {
+	ts, ok := repo.(infra.TextSearcher)
+	return ok && ts.SupportsTextSearch()
+}
+
+// searchInMemory выполняет поиск по Query для хранилищ без поиска:
+// выбирает все подписки по остальным фильтрам, отбрасывает несовпавшие,
+// упорядочивает по релевантности (если не задана сортировка) и применяет limit/offset.
+func (s *subscriptionService) searchInMemory(ctx context.Context, filter infra.ListFilter) ([]models.Subscription, error) {
+	q, limit, offset := *filter.Query, filter.Limit, filter.Offset
+	filter.Query, filter.Limit, filter.Offset = nil, 0, 0
+
+	all, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service List(): %w", fromRepo(err))
 	}
 
+	type scored struct {
+		item  models.Subscription
+		score float64
+	}
+	var found []scored
+	for _, item := range all {
+		if score, ok := search.Score(item.ServiceName, q); ok {
+			found = append(found, scored{item: item, score: score})
+		}
+	}
+	if len(filter.Sort) == 0 {
+		sort.SliceStable(found, func(i, j int) bool {
+			if found[i].score != found[j].score {
+				return found[i].score > found[j].score
+			}
+			return found[i].item.ID > found[j].item.ID
+		})
+	}
+
+	if offset >= len(found) {
+		return []models.Subscription{}, nil
+	}
+	found = found[offset:]
+	if limit > 0 && limit < len(found) {
+		found = found[:limit]
+	}
+
+	res := make([]models.Subscription, 0, len(found))
+	for _, f := range found {
+		res = append(res, f.item)
+	}
+	return res, nil
+}
+
+func (s *subscriptionService) TotalCost(ctx context.Context, periodStart, periodEnd time.Time, filter services.ListFilter) (int, error) {
 	ps := normalizeDate(periodStart)
 	pe := normalizeDate(periodEnd)
 
 	if pe.Before(ps) {
-		return 0, fmt.Errorf("%w: end_date не может быть раньше start_date", services.ErrValidation)
+		return 0, services.NewValidationError(errPeriodEndBeforeStart)
 	}
 
 	filter.Limit, filter.Offset = 0, 0
+	filter.IncludeShared = len(filter.UserIDs) > 0
 
 	data, err := s.List(ctx, filter)
 	if err != nil {
@@ -106,27 +321,441 @@ func (s *subscriptionService) TotalCost(ctx context.Context, periodStart, period
 
 	sum := 0
 	for _, item := range data {
-		start := normalizeDate(item.StartDate)
-		if start.Before(ps) {
-			start = ps
+		sum += periodCost(item, ps, pe, filter.UserIDs)
+	}
+	return sum, nil
+}
+
+// TotalCostByTeam - сумма за период с разбивкой по командам; подписки без команды
+// собраны в строку с TeamID == nil. Строки упорядочены по id команды, без команды - последней.
+func (s *subscriptionService) TotalCostByTeam(ctx context.Context, periodStart, periodEnd time.Time, filter services.ListFilter) ([]services.TeamCost, error) {
+	ps := normalizeDate(periodStart)
+	pe := normalizeDate(periodEnd)
+
+	if pe.Before(ps) {
+		return nil, services.NewValidationError(errPeriodEndBeforeStart)
+	}
+
+	filter.Limit, filter.Offset = 0, 0
+	filter.IncludeShared = len(filter.UserIDs) > 0
+
+	data, err := s.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service TotalCostByTeam(): %w", err)
+	}
+
+	var (
+		byTeam   = make(map[int]int)
+		noTeam   int
+		seenNone bool
+	)
+	for _, item := range data {
+		cost := periodCost(item, ps, pe, filter.UserIDs)
+		if item.TeamID == nil {
+			noTeam += cost
+			seenNone = true
+			continue
+		}
+		byTeam[*item.TeamID] += cost
+	}
+
+	res := make([]services.TeamCost, 0, len(byTeam)+1)
+	for teamID, cost := range byTeam {
+		res = append(res, services.TeamCost{TeamID: &teamID, TotalCost: cost})
+	}
+	sort.Slice(res, func(i, j int) bool { return *res[i].TeamID < *res[j].TeamID })
+	if seenNone {
+		res = append(res, services.TeamCost{TotalCost: noTeam})
+	}
+	return res, nil
+}
+
+// chargedPrice - месячная сумма, которую платят пользователи userIDs; без пользователей - вся цена.
+func chargedPrice(item models.Subscription, userIDs []string) int {
+	if len(userIDs) == 0 {
+		return item.Price
+	}
+	price := 0
+	for userID, share := range item.Shares() {
+		if slices.Contains(userIDs, userID) {
+			price += share
 		}
+	}
+	return price
+}
+
+// Forecast считает каждый месяц тем же periodCost, что и TotalCost, поэтому сумма прогноза
+// совпадает с TotalCost за тот же период.
+func (s *subscriptionService) Forecast(ctx context.Context, from time.Time, months int, filter services.ListFilter) ([]services.MonthCost, error) {
+	if months < 1 || months > services.MaxForecastMonths {
+		return nil, services.NewValidationError(errForecastMonths)
+	}
+	if from.IsZero() {
+		from = s.now()
+	}
+	ps := normalizeDate(from)
 
-		end := pe
-		if item.EndDate != nil {
-			e := normalizeDate(*item.EndDate)
-			if e.Before(end) {
-				end = e
+	filter.Limit, filter.Offset = 0, 0
+	filter.IncludeShared = len(filter.UserIDs) > 0
+
+	data, err := s.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service Forecast(): %w", err)
+	}
+
+	res := make([]services.MonthCost, months)
+	for i := range res {
+		res[i].Month = ps.AddDate(0, i, 0)
+	}
+	for _, item := range data {
+		for i := range res {
+			res[i].TotalCost += periodCost(item, res[i].Month, res[i].Month, filter.UserIDs)
+		}
+	}
+	return res, nil
+}
+
+// periodCost - сумма, которую пользователи userIDs (без пользователей - все плательщики) платят
+// за подписку за платные месяцы её действия внутри периода [ps, pe] с учётом запланированных изменений цены.
+// Месяцы пробного периода и пауз бесплатны.
+func periodCost(item models.Subscription, ps, pe time.Time, userIDs []string) int {
+	start := normalizeDate(item.StartDate)
+	if item.TrialEnd != nil {
+		start = normalizeDate(*item.TrialEnd).AddDate(0, 1, 0)
+	}
+	if start.Before(ps) {
+		start = ps
+	}
+
+	end := pe
+	if item.EndDate != nil {
+		e := normalizeDate(*item.EndDate)
+		if e.Before(end) {
+			end = e
+		}
+	}
+
+	// Период делится на отрезки с постоянной ценой и без смены паузы: от start до месяца
+	// перед следующим изменением цены, началом или концом паузы.
+	sum := 0
+	for !end.Before(start) {
+		segmentEnd := end
+		if next, ok := nextBoundary(item, start); ok {
+			if prev := next.AddDate(0, -1, 0); prev.Before(segmentEnd) {
+				segmentEnd = prev
 			}
 		}
-		if end.Before(start) {
+
+		if !item.PausedAt(start) {
+			priced := item
+			priced.Price = item.PriceAt(start)
+			months := (segmentEnd.Year()-start.Year())*12 + int(segmentEnd.Month()) - int(start.Month()) + 1
+			sum += months * chargedPrice(priced, userIDs)
+		}
+		start = segmentEnd.AddDate(0, 1, 0)
+	}
+	return sum
+}
+
+// nextBoundary - ближайший после month месяц, с которого меняется цена подписки или начинается либо заканчивается пауза.
+func nextBoundary(item models.Subscription, month time.Time) (time.Time, bool) {
+	var (
+		res   time.Time
+		found bool
+	)
+	consider := func(t time.Time) {
+		if t = normalizeDate(t); t.After(month) && (!found || t.Before(res)) {
+			res, found = t, true
+		}
+	}
+	for _, c := range item.PriceChanges {
+		consider(c.EffectiveFrom)
+	}
+	for _, p := range item.Pauses {
+		consider(p.Start)
+		if p.End != nil {
+			consider(normalizeDate(*p.End).AddDate(0, 1, 0))
+		}
+	}
+	return res, found
+}
+
+func (s *subscriptionService) FindOverlaps(ctx context.Context, data models.Subscription) ([]models.Subscription, error) {
+	candidates, err := s.List(ctx, services.ListFilter{
+		UserIDs:      []string{data.UserID},
+		ServiceNames: []string{data.ServiceName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("service FindOverlaps(): %w", err)
+	}
+
+	var res []models.Subscription
+	for _, item := range candidates {
+		if item.ID != data.ID && overlaps(item, data) {
+			res = append(res, item)
+		}
+	}
+	return res, nil
+}
+
+func (s *subscriptionService) Duplicates(ctx context.Context, filter services.ListFilter) ([]services.DuplicateGroup, error) {
+	filter.Limit, filter.Offset = 0, 0
+
+	data, err := s.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("service Duplicates(): %w", err)
+	}
+
+	type groupKey struct{ userID, serviceName string }
+	groups := make(map[groupKey][]models.Subscription)
+	for _, item := range data {
+		key := groupKey{item.UserID, item.ServiceName}
+		groups[key] = append(groups[key], item)
+	}
+
+	res := make([]services.DuplicateGroup, 0)
+	for key, items := range groups {
+		var dups []models.Subscription
+		for i, a := range items {
+			for j, b := range items {
+				if i != j && overlaps(a, b) {
+					dups = append(dups, a)
+					break
+				}
+			}
+		}
+		if len(dups) < 2 {
 			continue
 		}
+		sort.Slice(dups, func(i, j int) bool { return dups[i].ID < dups[j].ID })
+		res = append(res, services.DuplicateGroup{
+			UserID:        key.userID,
+			ServiceName:   key.serviceName,
+			Subscriptions: dups,
+		})
+	}
 
-		months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month()) + 1
-		if months > 0 {
-			sum += months * item.Price
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].UserID != res[j].UserID {
+			return res[i].UserID < res[j].UserID
 		}
+		return res[i].ServiceName < res[j].ServiceName
+	})
+	return res, nil
+}
 
+func (s *subscriptionService) SetParticipant(ctx context.Context, id int, data models.Participant) error {
+	if err := validateParticipant(data); err != nil {
+		return err
 	}
-	return sum, nil
+	if err := s.repo.SetParticipant(ctx, id, data); err != nil {
+		if errors.Is(err, infra.ErrForeignKey) {
+			return services.ErrNotFound
+		}
+		return fmt.Errorf("service SetParticipant(): %w", fromRepo(err))
+	}
+	return nil
+}
+
+func (s *subscriptionService) RemoveParticipant(ctx context.Context, id int, userID string) error {
+	if err := s.repo.RemoveParticipant(ctx, id, userID); err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return services.ErrNotFound
+		}
+		return fmt.Errorf("service RemoveParticipant(): %w", fromRepo(err))
+	}
+	return nil
+}
+
+func (s *subscriptionService) SetPriceChange(ctx context.Context, id int, data models.PriceChange) error {
+	data.EffectiveFrom = normalizeDate(data.EffectiveFrom)
+	if data.Price < 0 {
+		return services.NewValidationError(errPriceChangePrice)
+	}
+
+	sub, err := s.repo.GetByID(infra.WithPrimaryReads(ctx), id)
+	if err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return services.ErrNotFound
+		}
+		return fmt.Errorf("service SetPriceChange(): %w", err)
+	}
+	if !data.EffectiveFrom.After(normalizeDate(sub.StartDate)) {
+		return services.NewValidationError(errPriceChangeBeforeStart)
+	}
+
+	if err := s.repo.SetPriceChange(ctx, id, data); err != nil {
+		if errors.Is(err, infra.ErrForeignKey) {
+			return services.ErrNotFound
+		}
+		return fmt.Errorf("service SetPriceChange(): %w", fromRepo(err))
+	}
+	return nil
+}
+
+func (s *subscriptionService) RemovePriceChange(ctx context.Context, id int, effectiveFrom time.Time) error {
+	if err := s.repo.RemovePriceChange(ctx, id, normalizeDate(effectiveFrom)); err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return services.ErrNotFound
+		}
+		return fmt.Errorf("service RemovePriceChange(): %w", fromRepo(err))
+	}
+	return nil
+}
+
+func (s *subscriptionService) Pause(ctx context.Context, id int, data models.Pause) error {
+	if data.Start.IsZero() {
+		data.Start = s.now()
+	}
+	data.Start = normalizeDate(data.Start)
+	if data.End != nil {
+		end := normalizeDate(*data.End)
+		data.End = &end
+	}
+
+	sub, err := s.repo.GetByID(infra.WithPrimaryReads(ctx), id)
+	if err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return services.ErrNotFound
+		}
+		return fmt.Errorf("service Pause(): %w", err)
+	}
+	if err := validatePause(sub, data); err != nil {
+		return err
+	}
+
+	if err := s.repo.AddPause(ctx, id, data); err != nil {
+		if errors.Is(err, infra.ErrForeignKey) {
+			return services.ErrNotFound
+		}
+		return fmt.Errorf("service Pause(): %w", fromRepo(err))
+	}
+	return nil
+}
+
+func (s *subscriptionService) Resume(ctx context.Context, id int, from time.Time) error {
+	if from.IsZero() {
+		from = s.now()
+	}
+	if _, err := s.repo.GetByID(infra.WithPrimaryReads(ctx), id); err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return services.ErrNotFound
+		}
+		return fmt.Errorf("service Resume(): %w", err)
+	}
+
+	if err := s.repo.ClosePause(ctx, id, normalizeDate(from).AddDate(0, -1, 0)); err != nil {
+		if errors.Is(err, infra.ErrNotFound) {
+			return fmt.Errorf("%w: подписка %d не приостановлена", services.ErrConflict, id)
+		}
+		return fmt.Errorf("service Resume(): %w", fromRepo(err))
+	}
+	return nil
+}
+
+// checkOverlaps отклоняет запись в режиме OverlapReject, если у пользователя уже есть
+// пересекающаяся подписка на тот же сервис. В режиме OverlapWarn решение остаётся за вызывающим.
+func (s *subscriptionService) checkOverlaps(ctx context.Context, data models.Subscription) error {
+	if mode, ok := services.OverlapModeFromContext(ctx); !ok || mode != services.OverlapReject {
+		return nil
+	}
+
+	// Решение об отказе в записи не должно зависеть от отставания реплики.
+	found, err := s.FindOverlaps(infra.WithPrimaryReads(ctx), data)
+	if err != nil {
+		return err
+	}
+	if len(found) > 0 {
+		return &services.OverlapError{Overlaps: found}
+	}
+	return nil
+}
+
+func normalizeDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)
+}
+
+// overlaps - пересекаются ли периоды действия подписок с точностью до месяца.
+func overlaps(a, b models.Subscription) bool {
+	aStart, bStart := normalizeDate(a.StartDate), normalizeDate(b.StartDate)
+	if a.EndDate != nil && normalizeDate(*a.EndDate).Before(bStart) {
+		return false
+	}
+	if b.EndDate != nil && normalizeDate(*b.EndDate).Before(aStart) {
+		return false
+	}
+	return true
+}
+
+// validate проверяет инварианты подписки и возвращает все нарушения сразу.
+func validate(data models.Subscription) error {
+	var fields []services.FieldError
+	if data.Price < 0 {
+		fields = append(fields, errNegativePrice)
+	}
+	if data.EndDate != nil && data.EndDate.Before(data.StartDate) {
+		fields = append(fields, errEndBeforeStart)
+	}
+	if data.TrialEnd != nil && normalizeDate(*data.TrialEnd).Before(normalizeDate(data.StartDate)) {
+		fields = append(fields, errTrialBeforeStart)
+	}
+	if len(fields) > 0 {
+		return services.NewValidationError(fields...)
+	}
+	return nil
+}
+
+// validatePause - пауза начинается в период действия подписки и не пересекается с другими паузами.
+func validatePause(sub models.Subscription, data models.Pause) error {
+	var fields []services.FieldError
+	if data.Start.Before(normalizeDate(sub.StartDate)) {
+		fields = append(fields, errPauseBeforeStart)
+	}
+	if sub.EndDate != nil && data.Start.After(normalizeDate(*sub.EndDate)) {
+		fields = append(fields, errPauseAfterEnd)
+	}
+	if data.End != nil && data.End.Before(data.Start) {
+		fields = append(fields, errPauseUntil)
+	}
+	if len(fields) > 0 {
+		return services.NewValidationError(fields...)
+	}
+
+	for _, p := range sub.Pauses {
+		pStart := normalizeDate(p.Start)
+		if (data.End == nil || !data.End.Before(pStart)) && (p.End == nil || !normalizeDate(*p.End).Before(data.Start)) {
+			return services.NewValidationError(errPauseOverlap)
+		}
+	}
+	return nil
+}
+
+// validateParticipant - участник платит либо долю по положительному весу, либо неотрицательную фиксированную сумму.
+func validateParticipant(data models.Participant) error {
+	switch {
+	case (data.ShareWeight == nil) == (data.FixedAmount == nil):
+		return services.NewValidationError(errParticipantShare)
+	case data.ShareWeight != nil && *data.ShareWeight <= 0:
+		return services.NewValidationError(errShareWeight)
+	case data.FixedAmount != nil && *data.FixedAmount < 0:
+		return services.NewValidationError(errFixedAmount)
+	}
+	return nil
+}
+
+// constraintFields - ошибки полей для именованных CHECK и FOREIGN KEY ограничений таблиц подписок, их участников и изменений цены.
+var constraintFields = map[string]services.FieldError{
+	"subscriptions_price_check":                    errNegativePrice,
+	"subscriptions_check":                          errEndBeforeStart,
+	"subscriptions_team_id_fkey":                   errTeamNotFound,
+	"subscriptions_trial_end_check":                errTrialBeforeStart,
+	"subscription_participants_share_weight_check": errShareWeight,
+	"subscription_participants_fixed_amount_check": errFixedAmount,
+	"subscription_participants_check":              errParticipantShare,
+	"subscription_price_changes_price_check":       errPriceChangePrice,
+	"subscription_pauses_check":                    errPauseUntil,
+}
+
+func fromRepo(err error) error {
+	return services.FromRepo(err, services.FieldError{}, constraintFields)
 }
@@ -3,6 +3,8 @@ package subscription_service_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -20,6 +22,11 @@ func ym(y int, m time.Month) time.Time {
 	return time.Date(y, m, 1, 0, 0, 0, 0, time.Local)
 }
 
+// primaryCtx проверяет, что проверка пересечений читает с primary.
+var primaryCtx = mock.MatchedBy(func(ctx context.Context) bool {
+	return infra.PrimaryReadsFromContext(ctx)
+})
+
 // CREATE Tests
 func TestService_Create_OK(t *testing.T) {
 	ctx := context.Background()
@@ -117,6 +124,31 @@ func TestService_Create_ErrValidation_EndDateBeforeStartDate(t *testing.T) {
 	require.True(t, errors.Is(err, services.ErrValidation))
 }
 
+func TestService_Create_ErrValidation_AllFields(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	endDate := ym(2025, time.June)
+	in := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       -1,
+		UserID:      "u-1",
+		StartDate:   ym(2025, time.July),
+		EndDate:     &endDate,
+	}
+
+	_, err := svc.Create(ctx, in)
+
+	var validationErr *services.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Fields, 2)
+	require.Equal(t, "price", validationErr.Fields[0].Field)
+	require.Equal(t, services.CodeNegative, validationErr.Fields[0].Code)
+	require.Equal(t, "end_date", validationErr.Fields[1].Field)
+	require.Equal(t, services.CodeBeforeStart, validationErr.Fields[1].Code)
+}
+
 func TestService_Create_ErrDatabase(t *testing.T) {
 	ctx := context.Background()
 	repo := mocks.NewDatabase(t)
@@ -137,6 +169,67 @@ func TestService_Create_ErrDatabase(t *testing.T) {
 }
 
 // READ Tests
+func TestService_Create_ErrValidation_CheckViolation(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	in := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserID:      "u-1",
+		StartDate:   ym(2025, time.July),
+	}
+
+	repo.EXPECT().Create(ctx, in).Return(-1, fmt.Errorf("postgres Create(): %w", &infra.ConstraintError{
+		Err:        infra.ErrCheckViolation,
+		Constraint: "subscriptions_check",
+	}))
+
+	_, err := svc.Create(ctx, in)
+
+	var validationErr *services.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, "end_date", validationErr.Fields[0].Field)
+}
+
+func TestService_Create_ErrValidation_InvalidInput(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	in := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserID:      "u-1",
+		StartDate:   ym(2025, time.July),
+	}
+
+	repo.EXPECT().Create(ctx, in).Return(-1, &infra.ConstraintError{Err: infra.ErrInvalidInput})
+
+	_, err := svc.Create(ctx, in)
+	require.ErrorIs(t, err, services.ErrValidation)
+}
+
+func TestService_Update_ErrConflict_UniqueViolation(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	in := models.Subscription{
+		ID:          1,
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserID:      "u-1",
+		StartDate:   ym(2025, time.July),
+	}
+
+	repo.EXPECT().Update(ctx, in).Return(&infra.ConstraintError{Err: infra.ErrUniqueViolation})
+
+	err := svc.Update(ctx, in)
+	require.ErrorIs(t, err, services.ErrConflict)
+}
+
 func TestService_GetByID_OK(t *testing.T) {
 	ctx := context.Background()
 	repo := mocks.NewDatabase(t)
@@ -330,12 +423,10 @@ func TestService_List_OK_AllFilters(t *testing.T) {
 	svc := subscription_service.New(repo)
 
 	filter := services.ListFilter{
-		UserID:         "u-1",
-		HasUserID:      true,
-		ServiceName:    "Yandex Plus",
-		HasServiceName: true,
-		Limit:          10,
-		Offset:         20,
+		UserIDs:      []string{"u-1", "u-2"},
+		ServiceNames: []string{"Yandex Plus"},
+		Limit:        10,
+		Offset:       20,
 	}
 
 	want := []models.Subscription{
@@ -350,8 +441,8 @@ func TestService_List_OK_AllFilters(t *testing.T) {
 	}
 
 	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
-		return ifl.UserID != nil && *ifl.UserID == "u-1" &&
-			ifl.ServiceName != nil && *ifl.ServiceName == "Yandex Plus" &&
+		return slices.Equal(ifl.UserIDs, []string{"u-1", "u-2"}) &&
+			slices.Equal(ifl.ServiceNames, []string{"Yandex Plus"}) &&
 			ifl.Limit == 10 && ifl.Offset == 20
 	})).Return(want, nil)
 
@@ -366,15 +457,14 @@ func TestService_List_OK_UserOnly(t *testing.T) {
 	svc := subscription_service.New(repo)
 
 	filter := services.ListFilter{
-		UserID:    "u-1",
-		HasUserID: true,
-		Limit:     25,
-		Offset:    5,
+		UserIDs: []string{"u-1"},
+		Limit:   25,
+		Offset:  5,
 	}
 
 	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
-		return ifl.UserID != nil && *ifl.UserID == "u-1" &&
-			ifl.ServiceName == nil && ifl.Limit == 25 && ifl.Offset == 5
+		return slices.Equal(ifl.UserIDs, []string{"u-1"}) &&
+			ifl.ServiceNames == nil && ifl.Limit == 25 && ifl.Offset == 5
 	})).Return([]models.Subscription{}, nil)
 
 	_, err := svc.List(ctx, filter)
@@ -392,13 +482,124 @@ func TestService_List_OK_NoFilters(t *testing.T) {
 	}
 
 	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
-		return ifl.UserID == nil && ifl.ServiceName == nil && ifl.Limit == 10 && ifl.Offset == 20
+		return ifl.UserIDs == nil && ifl.ServiceNames == nil && ifl.Limit == 10 && ifl.Offset == 20
+	})).Return([]models.Subscription{}, nil)
+
+	_, err := svc.List(ctx, filter)
+	require.NoError(t, err)
+}
+
+func TestService_List_OK_ChangedAfter(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	created := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 7, 2, 12, 30, 0, 0, time.UTC)
+	filter := services.ListFilter{
+		CreatedAfter:    created,
+		HasCreatedAfter: true,
+		UpdatedAfter:    updated,
+		HasUpdatedAfter: true,
+		Limit:           50,
+	}
+
+	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
+		return ifl.CreatedAfter != nil && ifl.CreatedAfter.Equal(created) &&
+			ifl.UpdatedAfter != nil && ifl.UpdatedAfter.Equal(updated)
+	})).Return([]models.Subscription{}, nil)
+
+	_, err := svc.List(ctx, filter)
+	require.NoError(t, err)
+}
+
+func TestService_List_OK_RangesAndStatus(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	filter := services.ListFilter{
+		PriceMin: 100, HasPriceMin: true,
+		PriceMax: 500, HasPriceMax: true,
+		StartFrom: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), HasStartFrom: true,
+		ActiveAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), HasActiveAt: true,
+		Status: services.StatusActive,
+	}
+
+	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
+		return ifl.PriceMin != nil && *ifl.PriceMin == 100 &&
+			ifl.PriceMax != nil && *ifl.PriceMax == 500 &&
+			ifl.StartFrom != nil && ifl.StartFrom.Day() == 1 && ifl.StartFrom.Month() == time.January &&
+			ifl.StartTo == nil &&
+			ifl.ActiveAt != nil && ifl.ActiveAt.Month() == time.July &&
+			ifl.Status == "active" && !ifl.StatusAt.IsZero() && ifl.StatusAt.Day() == 1
+	})).Return([]models.Subscription{}, nil)
+
+	_, err := svc.List(ctx, filter)
+	require.NoError(t, err)
+}
+
+func TestService_List_OK_Sort(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	filter := services.ListFilter{
+		Sort: []services.SortKey{{Field: "price", Desc: true}, {Field: "service_name"}},
+	}
+
+	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
+		return len(ifl.Sort) == 2 &&
+			ifl.Sort[0] == infra.SortKey{Column: "price", Desc: true} &&
+			ifl.Sort[1] == infra.SortKey{Column: "service_name"}
 	})).Return([]models.Subscription{}, nil)
 
 	_, err := svc.List(ctx, filter)
 	require.NoError(t, err)
 }
 
+func TestService_List_Query_InMemoryFallback(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
+		return ifl.Query == nil && ifl.Limit == 0 && ifl.Offset == 0
+	})).Return([]models.Subscription{
+		{ID: 4, ServiceName: "Okko"},
+		{ID: 3, ServiceName: "My Netflix"},
+		{ID: 2, ServiceName: "Netflix Premium"},
+		{ID: 1, ServiceName: "Netflix"},
+	}, nil)
+
+	subs, err := svc.List(ctx, services.ListFilter{Query: "netflix", HasQuery: true, Limit: 2, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+	require.Equal(t, 2, subs[0].ID)
+	require.Equal(t, 3, subs[1].ID)
+}
+
+// searchingDB - хранилище, которое само выполняет поиск.
+type searchingDB struct {
+	*mocks.Database
+}
+
+func (searchingDB) SupportsTextSearch() bool { return true }
+
+func TestService_List_Query_PushedDown(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(searchingDB{repo})
+
+	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
+		return ifl.Query != nil && *ifl.Query == "netflix" && ifl.Limit == 10
+	})).Return([]models.Subscription{{ID: 1, ServiceName: "Netflix"}}, nil)
+
+	subs, err := svc.List(ctx, services.ListFilter{Query: "netflix", HasQuery: true, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+}
+
 func TestService_List_ErrDatabase(t *testing.T) {
 	ctx := context.Background()
 	repo := mocks.NewDatabase(t)
@@ -410,7 +611,7 @@ func TestService_List_ErrDatabase(t *testing.T) {
 	}
 
 	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
-		return ifl.UserID == nil && ifl.ServiceName == nil && ifl.Limit == 10 && ifl.Offset == 0
+		return ifl.UserIDs == nil && ifl.ServiceNames == nil && ifl.Limit == 10 && ifl.Offset == 0
 	})).Return(nil, errors.New("ошибка БД"))
 
 	subs, err := svc.List(ctx, filter)
@@ -528,15 +729,15 @@ func TestService_TotalCost_ListMapping(t *testing.T) {
 	svc := subscription_service.New(repo)
 
 	filter := services.ListFilter{
-		UserID: "u-1", HasUserID: true,
-		ServiceName: "Yandex Plus", HasServiceName: true,
+		UserIDs:      []string{"u-1"},
+		ServiceNames: []string{"Yandex Plus"},
 	}
 
 	periodStart, periodEnd := ym(2025, time.January), ym(2025, time.March)
 
 	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
-		return ifl.UserID != nil && *ifl.UserID == "u-1" &&
-			ifl.ServiceName != nil && *ifl.ServiceName == "Yandex Plus"
+		return slices.Equal(ifl.UserIDs, []string{"u-1"}) &&
+			slices.Equal(ifl.ServiceNames, []string{"Yandex Plus"})
 	})).Return([]models.Subscription{}, nil)
 
 	sum, err := svc.TotalCost(ctx, periodStart, periodEnd, filter)
@@ -580,3 +781,527 @@ func TestService_TotalCost_ErrDatabase(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorContains(t, err, "ошибка БД")
 }
+
+// Overlap Tests
+func TestService_Create_ErrConflict_OverlapReject(t *testing.T) {
+	ctx := services.WithOverlapMode(context.Background(), services.OverlapReject)
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	// Существующая подписка с января 2025 без конца пересекается с новой с июля 2025
+	in := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserID:      "u-1",
+		StartDate:   ym(2025, time.July),
+	}
+	existing := []models.Subscription{
+		{ID: 7, ServiceName: "Yandex Plus", Price: 400, UserID: "u-1", StartDate: ym(2025, time.January)},
+	}
+
+	repo.EXPECT().List(primaryCtx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
+		return slices.Equal(ifl.UserIDs, []string{"u-1"}) &&
+			slices.Equal(ifl.ServiceNames, []string{"Yandex Plus"}) && ifl.Limit == 0
+	})).Return(existing, nil)
+
+	_, err := svc.Create(ctx, in)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, services.ErrConflict))
+
+	var overlapErr *services.OverlapError
+	require.ErrorAs(t, err, &overlapErr)
+	require.Equal(t, existing, overlapErr.Overlaps)
+}
+
+func TestService_Create_OK_OverlapReject_NoIntersection(t *testing.T) {
+	ctx := services.WithOverlapMode(context.Background(), services.OverlapReject)
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	// Существующая подписка закончилась в июне 2025, новая начинается в июле 2025
+	endDate := ym(2025, time.June)
+	in := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserID:      "u-1",
+		StartDate:   ym(2025, time.July),
+	}
+
+	repo.EXPECT().List(primaryCtx, mock.AnythingOfType("infra.ListFilter")).Return([]models.Subscription{
+		{ID: 7, ServiceName: "Yandex Plus", Price: 400, UserID: "u-1", StartDate: ym(2025, time.January), EndDate: &endDate},
+	}, nil)
+	repo.EXPECT().Create(ctx, in).Return(8, nil)
+
+	id, err := svc.Create(ctx, in)
+	require.NoError(t, err)
+	require.Equal(t, 8, id)
+}
+
+func TestService_Create_OK_OverlapWarn(t *testing.T) {
+	ctx := services.WithOverlapMode(context.Background(), services.OverlapWarn)
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	in := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserID:      "u-1",
+		StartDate:   ym(2025, time.July),
+	}
+
+	repo.EXPECT().Create(ctx, in).Return(8, nil)
+
+	id, err := svc.Create(ctx, in)
+	require.NoError(t, err)
+	require.Equal(t, 8, id)
+}
+
+func TestService_Update_OK_OverlapReject_IgnoresItself(t *testing.T) {
+	ctx := services.WithOverlapMode(context.Background(), services.OverlapReject)
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	in := models.Subscription{
+		ID:          7,
+		ServiceName: "Yandex Plus",
+		Price:       500,
+		UserID:      "u-1",
+		StartDate:   ym(2025, time.January),
+	}
+
+	repo.EXPECT().List(primaryCtx, mock.AnythingOfType("infra.ListFilter")).Return([]models.Subscription{
+		{ID: 7, ServiceName: "Yandex Plus", Price: 400, UserID: "u-1", StartDate: ym(2025, time.January)},
+	}, nil)
+	repo.EXPECT().Update(ctx, in).Return(nil)
+
+	err := svc.Update(ctx, in)
+	require.NoError(t, err)
+}
+
+func TestService_Duplicates_OK(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	// u-1: две пересекающиеся подписки Yandex Plus и одна непересекающаяся; u-2: одна подписка
+	end1, end3 := ym(2025, time.March), ym(2024, time.June)
+	data := []models.Subscription{
+		{ID: 1, ServiceName: "Yandex Plus", Price: 400, UserID: "u-1", StartDate: ym(2025, time.January), EndDate: &end1},
+		{ID: 2, ServiceName: "Yandex Plus", Price: 400, UserID: "u-1", StartDate: ym(2025, time.March)},
+		{ID: 3, ServiceName: "Yandex Plus", Price: 400, UserID: "u-1", StartDate: ym(2024, time.January), EndDate: &end3},
+		{ID: 4, ServiceName: "Yandex Plus", Price: 400, UserID: "u-2", StartDate: ym(2025, time.January)},
+	}
+
+	repo.EXPECT().List(ctx, mock.MatchedBy(func(ifl infra.ListFilter) bool {
+		return ifl.Limit == 0 && ifl.Offset == 0
+	})).Return(data, nil)
+
+	groups, err := svc.Duplicates(ctx, services.ListFilter{Limit: 50})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, "u-1", groups[0].UserID)
+	require.Equal(t, "Yandex Plus", groups[0].ServiceName)
+	require.Len(t, groups[0].Subscriptions, 2)
+	require.Equal(t, 1, groups[0].Subscriptions[0].ID)
+	require.Equal(t, 2, groups[0].Subscriptions[1].ID)
+}
+
+func TestService_TotalCostByTeam_OK(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	periodStart, periodEnd := ym(2025, time.January), ym(2025, time.March)
+	team1, team2 := 1, 2
+	data := []models.Subscription{
+		{ID: 1, ServiceName: "Okko", Price: 100, UserID: "u-1", StartDate: periodStart, TeamID: &team2},
+		{ID: 2, ServiceName: "Kion", Price: 200, UserID: "u-1", StartDate: periodStart, TeamID: &team1},
+		{ID: 3, ServiceName: "Ivi", Price: 50, UserID: "u-2", StartDate: ym(2025, time.March)},
+		{ID: 4, ServiceName: "Start", Price: 10, UserID: "u-2", StartDate: periodStart, TeamID: &team2},
+	}
+	repo.EXPECT().List(ctx, mock.AnythingOfType("infra.ListFilter")).Return(data, nil)
+
+	res, err := svc.TotalCostByTeam(ctx, periodStart, periodEnd, services.ListFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []services.TeamCost{
+		{TeamID: &team1, TotalCost: 600},
+		{TeamID: &team2, TotalCost: 330},
+		{TotalCost: 50},
+	}, res)
+}
+
+func TestService_TotalCost_ChargesParticipantShares(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	periodStart, periodEnd := ym(2025, time.January), ym(2025, time.March)
+	weight := 1
+	data := []models.Subscription{
+		// Семейная подписка владельца u-1 на троих: доля u-2 - 100 в месяц.
+		{ID: 1, ServiceName: "Yandex Plus", Price: 300, UserID: "u-1", StartDate: periodStart, Participants: []models.Participant{
+			{UserID: "u-1", ShareWeight: &weight},
+			{UserID: "u-2", ShareWeight: &weight},
+			{UserID: "u-3", ShareWeight: &weight},
+		}},
+		// Собственная подписка u-2.
+		{ID: 2, ServiceName: "Okko", Price: 50, UserID: "u-2", StartDate: periodStart},
+	}
+	repo.EXPECT().List(ctx, mock.MatchedBy(func(f infra.ListFilter) bool {
+		return f.IncludeShared && len(f.UserIDs) == 1 && f.UserIDs[0] == "u-2"
+	})).Return(data, nil)
+
+	sum, err := svc.TotalCost(ctx, periodStart, periodEnd, services.ListFilter{UserIDs: []string{"u-2"}})
+	require.NoError(t, err)
+	require.Equal(t, 3*100+3*50, sum)
+}
+
+func TestService_SetParticipant_ErrValidation(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	weight, amount := 1, 100
+	for _, p := range []models.Participant{
+		{UserID: "u-2"},
+		{UserID: "u-2", ShareWeight: &weight, FixedAmount: &amount},
+	} {
+		err := svc.SetParticipant(ctx, 1, p)
+		var vErr *services.ValidationError
+		require.ErrorAs(t, err, &vErr)
+	}
+}
+
+func TestService_SetParticipant_ErrNotFound_NoSubscription(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	weight := 1
+	p := models.Participant{UserID: "u-2", ShareWeight: &weight}
+	repo.EXPECT().SetParticipant(ctx, 1, p).Return(&infra.ConstraintError{
+		Err:        infra.ErrForeignKey,
+		Constraint: "subscription_participants_subscription_id_fkey",
+	})
+
+	err := svc.SetParticipant(ctx, 1, p)
+	require.ErrorIs(t, err, services.ErrNotFound)
+}
+
+func TestService_TotalCost_PriceChanges(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	// Период: январь-июнь 2025, цена 400, с марта 500, с мая 600: 400*2 + 500*2 + 600*2 = 3000
+	data := []models.Subscription{
+		{
+			ID:        1,
+			Price:     400,
+			UserID:    "u-1",
+			StartDate: ym(2024, time.December),
+			PriceChanges: []models.PriceChange{
+				{EffectiveFrom: ym(2025, time.March), Price: 500},
+				{EffectiveFrom: ym(2025, time.May), Price: 600},
+			},
+		},
+	}
+	repo.EXPECT().List(ctx, mock.AnythingOfType("infra.ListFilter")).Return(data, nil)
+
+	sum, err := svc.TotalCost(ctx, ym(2025, time.January), ym(2025, time.June), services.ListFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 3000, sum)
+}
+
+func TestService_Forecast_OK(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	end := ym(2025, time.February)
+	data := []models.Subscription{
+		{
+			ID:           1,
+			Price:        400,
+			UserID:       "u-1",
+			StartDate:    ym(2024, time.June),
+			PriceChanges: []models.PriceChange{{EffectiveFrom: ym(2025, time.March), Price: 500}},
+		},
+		{ID: 2, Price: 100, UserID: "u-1", StartDate: ym(2024, time.June), EndDate: &end},
+		{ID: 3, Price: 50, UserID: "u-1", StartDate: ym(2025, time.March)},
+	}
+	repo.EXPECT().List(ctx, mock.AnythingOfType("infra.ListFilter")).Return(data, nil)
+
+	res, err := svc.Forecast(ctx, ym(2025, time.January), 4, services.ListFilter{})
+	require.NoError(t, err)
+	require.Equal(t, []services.MonthCost{
+		{Month: ym(2025, time.January), TotalCost: 500},
+		{Month: ym(2025, time.February), TotalCost: 500},
+		{Month: ym(2025, time.March), TotalCost: 550},
+		{Month: ym(2025, time.April), TotalCost: 550},
+	}, res)
+}
+
+func TestService_Forecast_ChargesParticipantShares(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	one, two := 1, 2
+	data := []models.Subscription{
+		{
+			ID:           1,
+			Price:        300,
+			UserID:       "u-1",
+			StartDate:    ym(2025, time.January),
+			Participants: []models.Participant{{UserID: "u-1", ShareWeight: &one}, {UserID: "u-2", ShareWeight: &two}},
+			PriceChanges: []models.PriceChange{{EffectiveFrom: ym(2025, time.February), Price: 600}},
+		},
+	}
+	repo.EXPECT().List(ctx, mock.MatchedBy(func(f infra.ListFilter) bool { return f.IncludeShared })).Return(data, nil)
+
+	res, err := svc.Forecast(ctx, ym(2025, time.January), 2, services.ListFilter{UserIDs: []string{"u-2"}})
+	require.NoError(t, err)
+	require.Equal(t, 200, res[0].TotalCost)
+	require.Equal(t, 400, res[1].TotalCost)
+}
+
+func TestService_Forecast_ErrValidation_Months(t *testing.T) {
+	svc := subscription_service.New(mocks.NewDatabase(t))
+
+	_, err := svc.Forecast(context.Background(), ym(2025, time.January), 0, services.ListFilter{})
+	var vErr *services.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, "months", vErr.Fields[0].Field)
+}
+
+func TestService_SetPriceChange_ErrValidation_BeforeStart(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().GetByID(mock.Anything, 1).Return(models.Subscription{ID: 1, StartDate: ym(2025, time.March)}, nil)
+
+	err := svc.SetPriceChange(ctx, 1, models.PriceChange{EffectiveFrom: ym(2025, time.March), Price: 500})
+	var vErr *services.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, "effective_from", vErr.Fields[0].Field)
+}
+
+func TestService_SetPriceChange_OK(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().GetByID(mock.Anything, 1).Return(models.Subscription{ID: 1, StartDate: ym(2025, time.March)}, nil)
+	repo.EXPECT().SetPriceChange(ctx, 1, models.PriceChange{EffectiveFrom: ym(2025, time.June), Price: 500}).Return(nil)
+
+	require.NoError(t, svc.SetPriceChange(ctx, 1, models.PriceChange{EffectiveFrom: ym(2025, time.June), Price: 500}))
+}
+
+func TestService_RemovePriceChange_ErrNotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().RemovePriceChange(ctx, 1, ym(2025, time.June)).Return(infra.ErrNotFound)
+
+	require.ErrorIs(t, svc.RemovePriceChange(ctx, 1, ym(2025, time.June)), services.ErrNotFound)
+}
+
+func TestService_Create_ErrValidation_TrialEndBeforeStartDate(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	trialEnd := ym(2025, time.June)
+	in := models.Subscription{
+		ServiceName: "Yandex Plus",
+		Price:       400,
+		UserID:      "u-1",
+		StartDate:   ym(2025, time.July),
+		TrialEnd:    &trialEnd,
+	}
+
+	_, err := svc.Create(ctx, in)
+	var vErr *services.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, "trial_end", vErr.Fields[0].Field)
+}
+
+func TestService_TotalCost_TrialMonthsFree(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	// Подписка с января, пробный период по февраль: за январь-апрель платятся март и апрель = 400*2 = 800
+	trialEnd := ym(2025, time.February)
+	data := []models.Subscription{
+		{ID: 1, Price: 400, UserID: "u-1", StartDate: ym(2025, time.January), TrialEnd: &trialEnd},
+	}
+	repo.EXPECT().List(ctx, mock.AnythingOfType("infra.ListFilter")).Return(data, nil)
+
+	sum, err := svc.TotalCost(ctx, ym(2025, time.January), ym(2025, time.April), services.ListFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 800, sum)
+}
+
+func TestService_Forecast_TrialMonthsFree(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	trialEnd := ym(2025, time.January)
+	data := []models.Subscription{
+		{ID: 1, Price: 400, UserID: "u-1", StartDate: ym(2025, time.January), TrialEnd: &trialEnd},
+	}
+	repo.EXPECT().List(ctx, mock.AnythingOfType("infra.ListFilter")).Return(data, nil)
+
+	res, err := svc.Forecast(ctx, ym(2025, time.January), 2, services.ListFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 0, res[0].TotalCost)
+	require.Equal(t, 400, res[1].TotalCost)
+}
+
+func TestService_List_TrialEndsWithin(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().List(ctx, mock.MatchedBy(func(f infra.ListFilter) bool {
+		return f.TrialEndFrom != nil && f.TrialEndTo != nil &&
+			f.TrialEndFrom.Day() == 1 && f.TrialEndTo.Equal(f.TrialEndFrom.AddDate(0, 1, 0))
+	})).Return(nil, nil)
+
+	_, err := svc.List(ctx, services.ListFilter{TrialEndsWithin: 1, HasTrialEndsWithin: true})
+	require.NoError(t, err)
+}
+
+func TestService_TotalCost_PausedMonthsFree(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	// Январь-июнь 2025, пауза март-апрель, с мая цена 500: 400*2 + 500*2 = 1800
+	pauseEnd := ym(2025, time.April)
+	data := []models.Subscription{
+		{
+			ID:           1,
+			Price:        400,
+			UserID:       "u-1",
+			StartDate:    ym(2025, time.January),
+			Pauses:       []models.Pause{{Start: ym(2025, time.March), End: &pauseEnd}},
+			PriceChanges: []models.PriceChange{{EffectiveFrom: ym(2025, time.May), Price: 500}},
+		},
+	}
+	repo.EXPECT().List(ctx, mock.AnythingOfType("infra.ListFilter")).Return(data, nil)
+
+	sum, err := svc.TotalCost(ctx, ym(2025, time.January), ym(2025, time.June), services.ListFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 1800, sum)
+}
+
+func TestService_Forecast_OpenPause(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	data := []models.Subscription{
+		{ID: 1, Price: 400, UserID: "u-1", StartDate: ym(2025, time.January), Pauses: []models.Pause{{Start: ym(2025, time.February)}}},
+	}
+	repo.EXPECT().List(ctx, mock.AnythingOfType("infra.ListFilter")).Return(data, nil)
+
+	res, err := svc.Forecast(ctx, ym(2025, time.January), 3, services.ListFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 400, res[0].TotalCost)
+	require.Equal(t, 0, res[1].TotalCost)
+	require.Equal(t, 0, res[2].TotalCost)
+}
+
+func TestService_Pause_OK(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().GetByID(mock.Anything, 1).Return(models.Subscription{ID: 1, StartDate: ym(2025, time.January)}, nil)
+	repo.EXPECT().AddPause(ctx, 1, models.Pause{Start: ym(2025, time.March)}).Return(nil)
+
+	require.NoError(t, svc.Pause(ctx, 1, models.Pause{Start: ym(2025, time.March)}))
+}
+
+func TestService_Pause_ErrValidation_Overlap(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	pauseEnd := ym(2025, time.April)
+	repo.EXPECT().GetByID(mock.Anything, 1).Return(models.Subscription{
+		ID:        1,
+		StartDate: ym(2025, time.January),
+		Pauses:    []models.Pause{{Start: ym(2025, time.March), End: &pauseEnd}},
+	}, nil)
+
+	until := ym(2025, time.March)
+	err := svc.Pause(ctx, 1, models.Pause{Start: ym(2025, time.February), End: &until})
+	var vErr *services.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, "from", vErr.Fields[0].Field)
+}
+
+func TestService_Pause_ErrValidation_BeforeStart(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().GetByID(mock.Anything, 1).Return(models.Subscription{ID: 1, StartDate: ym(2025, time.March)}, nil)
+
+	err := svc.Pause(ctx, 1, models.Pause{Start: ym(2025, time.February)})
+	require.ErrorIs(t, err, services.ErrValidation)
+}
+
+func TestService_Resume_OK(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().GetByID(mock.Anything, 1).Return(models.Subscription{ID: 1}, nil)
+	repo.EXPECT().ClosePause(ctx, 1, ym(2025, time.May)).Return(nil)
+
+	require.NoError(t, svc.Resume(ctx, 1, ym(2025, time.June)))
+}
+
+func TestService_PauseResume_DefaultToCurrentMonth(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+	now := time.Now()
+	month := ym(now.Year(), now.Month())
+
+	repo.EXPECT().GetByID(mock.Anything, 1).Return(models.Subscription{ID: 1, StartDate: ym(2020, time.January)}, nil)
+	repo.EXPECT().AddPause(ctx, 1, models.Pause{Start: month}).Return(nil)
+	repo.EXPECT().ClosePause(ctx, 1, month.AddDate(0, -1, 0)).Return(nil)
+
+	require.NoError(t, svc.Pause(ctx, 1, models.Pause{}))
+	require.NoError(t, svc.Resume(ctx, 1, time.Time{}))
+}
+
+func TestService_Resume_ErrConflict_NotPaused(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().GetByID(mock.Anything, 1).Return(models.Subscription{ID: 1}, nil)
+	repo.EXPECT().ClosePause(ctx, 1, mock.Anything).Return(infra.ErrNotFound)
+
+	require.ErrorIs(t, svc.Resume(ctx, 1, ym(2025, time.June)), services.ErrConflict)
+}
+
+func TestService_Resume_ErrNotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.New(repo)
+
+	repo.EXPECT().GetByID(mock.Anything, 1).Return(models.Subscription{}, infra.ErrNotFound)
+
+	require.ErrorIs(t, svc.Resume(ctx, 1, ym(2025, time.June)), services.ErrNotFound)
+}
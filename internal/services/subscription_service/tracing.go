@@ -0,0 +1,154 @@
+package subscription_service
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/tracing"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+var _ services.SubscriptionService = (*tracedService)(nil)
+
+// tracedService оборачивает каждый метод SubscriptionService в спан.
+type tracedService struct {
+	next services.SubscriptionService
+}
+
+func WithTracing(next services.SubscriptionService) services.SubscriptionService {
+	return &tracedService{next: next}
+}
+
+func startSpan(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "SubscriptionService."+method, trace.WithAttributes(attrs...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t *tracedService) Create(ctx context.Context, data models.Subscription) (int, error) {
+	ctx, span := startSpan(ctx, "Create")
+	id, err := t.next.Create(ctx, data)
+	span.SetAttributes(attribute.Int("subscription.id", id))
+	endSpan(span, err)
+	return id, err
+}
+
+func (t *tracedService) GetByID(ctx context.Context, id int) (models.Subscription, error) {
+	ctx, span := startSpan(ctx, "GetByID", attribute.Int("subscription.id", id))
+	res, err := t.next.GetByID(ctx, id)
+	endSpan(span, err)
+	return res, err
+}
+
+func (t *tracedService) Update(ctx context.Context, data models.Subscription) error {
+	ctx, span := startSpan(ctx, "Update", attribute.Int("subscription.id", data.ID))
+	err := t.next.Update(ctx, data)
+	endSpan(span, err)
+	return err
+}
+
+func (t *tracedService) Delete(ctx context.Context, id int) error {
+	ctx, span := startSpan(ctx, "Delete", attribute.Int("subscription.id", id))
+	err := t.next.Delete(ctx, id)
+	endSpan(span, err)
+	return err
+}
+
+func (t *tracedService) List(ctx context.Context, filter services.ListFilter) ([]models.Subscription, error) {
+	ctx, span := startSpan(ctx, "List")
+	res, err := t.next.List(ctx, filter)
+	span.SetAttributes(attribute.Int("result.count", len(res)))
+	endSpan(span, err)
+	return res, err
+}
+
+func (t *tracedService) TotalCost(ctx context.Context, start, end time.Time, filter services.ListFilter) (int, error) {
+	ctx, span := startSpan(ctx, "TotalCost")
+	sum, err := t.next.TotalCost(ctx, start, end, filter)
+	endSpan(span, err)
+	return sum, err
+}
+
+func (t *tracedService) TotalCostByTeam(ctx context.Context, start, end time.Time, filter services.ListFilter) ([]services.TeamCost, error) {
+	ctx, span := startSpan(ctx, "TotalCostByTeam")
+	res, err := t.next.TotalCostByTeam(ctx, start, end, filter)
+	span.SetAttributes(attribute.Int("result.count", len(res)))
+	endSpan(span, err)
+	return res, err
+}
+
+func (t *tracedService) Forecast(ctx context.Context, from time.Time, months int, filter services.ListFilter) ([]services.MonthCost, error) {
+	ctx, span := startSpan(ctx, "Forecast", attribute.Int("forecast.months", months))
+	res, err := t.next.Forecast(ctx, from, months, filter)
+	endSpan(span, err)
+	return res, err
+}
+
+func (t *tracedService) FindOverlaps(ctx context.Context, data models.Subscription) ([]models.Subscription, error) {
+	ctx, span := startSpan(ctx, "FindOverlaps", attribute.Int("subscription.id", data.ID))
+	res, err := t.next.FindOverlaps(ctx, data)
+	span.SetAttributes(attribute.Int("result.count", len(res)))
+	endSpan(span, err)
+	return res, err
+}
+
+func (t *tracedService) Duplicates(ctx context.Context, filter services.ListFilter) ([]services.DuplicateGroup, error) {
+	ctx, span := startSpan(ctx, "Duplicates")
+	res, err := t.next.Duplicates(ctx, filter)
+	span.SetAttributes(attribute.Int("result.count", len(res)))
+	endSpan(span, err)
+	return res, err
+}
+
+func (t *tracedService) SetParticipant(ctx context.Context, id int, data models.Participant) error {
+	ctx, span := startSpan(ctx, "SetParticipant", attribute.Int("subscription.id", id))
+	err := t.next.SetParticipant(ctx, id, data)
+	endSpan(span, err)
+	return err
+}
+
+func (t *tracedService) RemoveParticipant(ctx context.Context, id int, userID string) error {
+	ctx, span := startSpan(ctx, "RemoveParticipant", attribute.Int("subscription.id", id))
+	err := t.next.RemoveParticipant(ctx, id, userID)
+	endSpan(span, err)
+	return err
+}
+
+func (t *tracedService) SetPriceChange(ctx context.Context, id int, data models.PriceChange) error {
+	ctx, span := startSpan(ctx, "SetPriceChange", attribute.Int("subscription.id", id))
+	err := t.next.SetPriceChange(ctx, id, data)
+	endSpan(span, err)
+	return err
+}
+
+func (t *tracedService) RemovePriceChange(ctx context.Context, id int, effectiveFrom time.Time) error {
+	ctx, span := startSpan(ctx, "RemovePriceChange", attribute.Int("subscription.id", id))
+	err := t.next.RemovePriceChange(ctx, id, effectiveFrom)
+	endSpan(span, err)
+	return err
+}
+
+func (t *tracedService) Pause(ctx context.Context, id int, data models.Pause) error {
+	ctx, span := startSpan(ctx, "Pause", attribute.Int("subscription.id", id))
+	err := t.next.Pause(ctx, id, data)
+	endSpan(span, err)
+	return err
+}
+
+func (t *tracedService) Resume(ctx context.Context, id int, from time.Time) error {
+	ctx, span := startSpan(ctx, "Resume", attribute.Int("subscription.id", id))
+	err := t.next.Resume(ctx, id, from)
+	endSpan(span, err)
+	return err
+}
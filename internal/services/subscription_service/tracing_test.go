@@ -0,0 +1,69 @@
+package subscription_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
+	"github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+	"github.com/sunr3d/subscription-aggregator/internal/services/subscription_service"
+	"github.com/sunr3d/subscription-aggregator/mocks"
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return sr
+}
+
+func TestTracedService_GetByID_OK(t *testing.T) {
+	sr := setupRecorder(t)
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.WithTracing(subscription_service.New(repo))
+
+	// В репозиторий должен уйти контекст со спаном сервиса
+	var repoSC trace.SpanContext
+	repo.EXPECT().GetByID(mock.Anything, 1).
+		Run(func(ctx context.Context, _ int) { repoSC = trace.SpanContextFromContext(ctx) }).
+		Return(models.Subscription{ID: 1}, nil)
+
+	_, err := svc.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "SubscriptionService.GetByID", spans[0].Name())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, spans[0].SpanContext().SpanID(), repoSC.SpanID())
+}
+
+func TestTracedService_Delete_ErrRecorded(t *testing.T) {
+	sr := setupRecorder(t)
+	repo := mocks.NewDatabase(t)
+	svc := subscription_service.WithTracing(subscription_service.New(repo))
+
+	repo.EXPECT().Delete(mock.Anything, 1).Return(infra.ErrNotFound)
+
+	err := svc.Delete(context.Background(), 1)
+	require.True(t, errors.Is(err, services.ErrNotFound))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "SubscriptionService.Delete", spans[0].Name())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.NotEmpty(t, spans[0].Events())
+}
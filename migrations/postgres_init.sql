@@ -10,4 +10,113 @@ CREATE TABLE IF NOT EXISTS subscriptions (
 
 CREATE INDEX IF NOT EXISTS idx_sub_user_id ON subscriptions (user_id);
 CREATE INDEX IF NOT EXISTS idx_sub_service_name ON subscriptions (service_name);
-CREATE INDEX IF NOT EXISTS idx_sub_start_date ON subscriptions (start_date);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_sub_start_date ON subscriptions (start_date);
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key TEXT PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    status_code INT NULL,
+    content_type TEXT NULL,
+    response_body BYTEA NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_expires_at ON idempotency_keys (expires_at);
+
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS idx_sub_created_at ON subscriptions (created_at);
+CREATE INDEX IF NOT EXISTS idx_sub_updated_at ON subscriptions (updated_at);
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_sub_service_name_trgm ON subscriptions USING GIN (service_name gin_trgm_ops);
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL CHECK (name <> ''),
+    owner_id UUID NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    name TEXT NOT NULL CHECK (name <> ''),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (organization_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id BIGINT NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('member', 'admin')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_user_id ON team_members (user_id);
+
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS team_id BIGINT NULL REFERENCES teams (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sub_team_id ON subscriptions (team_id);
+
+CREATE TABLE IF NOT EXISTS subscription_participants (
+    subscription_id BIGINT NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    share_weight INT NULL CHECK (share_weight > 0),
+    fixed_amount INT NULL CHECK (fixed_amount >= 0),
+    PRIMARY KEY (subscription_id, user_id),
+    CHECK ((share_weight IS NULL) <> (fixed_amount IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_sub_participants_user_id ON subscription_participants (user_id);
+
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_sub_category ON subscriptions (category);
+
+CREATE TABLE IF NOT EXISTS budgets (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NULL,
+    team_id BIGINT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    period TEXT NOT NULL CHECK (period IN ('monthly', 'yearly')),
+    amount INT NOT NULL CHECK (amount > 0),
+    category TEXT NOT NULL DEFAULT '',
+    service_name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK ((user_id IS NULL) <> (team_id IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS budget_alerts (
+    budget_id BIGINT NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    threshold INT NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (budget_id, period_start, threshold)
+);
+
+CREATE TABLE IF NOT EXISTS subscription_price_changes (
+    subscription_id BIGINT NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    effective_from DATE NOT NULL,
+    price INT NOT NULL CHECK (price >= 0),
+    PRIMARY KEY (subscription_id, effective_from)
+);
+
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS trial_end DATE NULL
+    CONSTRAINT subscriptions_trial_end_check CHECK (trial_end >= start_date);
+
+CREATE INDEX IF NOT EXISTS idx_sub_trial_end ON subscriptions (trial_end) WHERE trial_end IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS subscription_pauses (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    start_month DATE NOT NULL,
+    end_month DATE NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (end_month >= start_month)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sub_pauses_subscription_id ON subscription_pauses (subscription_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sub_pauses_open ON subscription_pauses (subscription_id) WHERE end_month IS NULL;
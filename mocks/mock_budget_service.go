@@ -0,0 +1,256 @@
+// Code generated by mockery v2.53.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/subscription-aggregator/models"
+
+	services "github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+)
+
+// BudgetService is an autogenerated mock type for the BudgetService type
+type BudgetService struct {
+	mock.Mock
+}
+
+type BudgetService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *BudgetService) EXPECT() *BudgetService_Expecter {
+	return &BudgetService_Expecter{mock: &_m.Mock}
+}
+
+// CreateBudget provides a mock function with given fields: ctx, data
+func (_m *BudgetService) CreateBudget(ctx context.Context, data models.Budget) (int, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBudget")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Budget) (int, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Budget) int); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Budget) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BudgetService_CreateBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBudget'
+type BudgetService_CreateBudget_Call struct {
+	*mock.Call
+}
+
+// CreateBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data models.Budget
+func (_e *BudgetService_Expecter) CreateBudget(ctx interface{}, data interface{}) *BudgetService_CreateBudget_Call {
+	return &BudgetService_CreateBudget_Call{Call: _e.mock.On("CreateBudget", ctx, data)}
+}
+
+func (_c *BudgetService_CreateBudget_Call) Run(run func(ctx context.Context, data models.Budget)) *BudgetService_CreateBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Budget))
+	})
+	return _c
+}
+
+func (_c *BudgetService_CreateBudget_Call) Return(_a0 int, _a1 error) *BudgetService_CreateBudget_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BudgetService_CreateBudget_Call) RunAndReturn(run func(context.Context, models.Budget) (int, error)) *BudgetService_CreateBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EvaluateAlerts provides a mock function with given fields: ctx
+func (_m *BudgetService) EvaluateAlerts(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateAlerts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BudgetService_EvaluateAlerts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvaluateAlerts'
+type BudgetService_EvaluateAlerts_Call struct {
+	*mock.Call
+}
+
+// EvaluateAlerts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BudgetService_Expecter) EvaluateAlerts(ctx interface{}) *BudgetService_EvaluateAlerts_Call {
+	return &BudgetService_EvaluateAlerts_Call{Call: _e.mock.On("EvaluateAlerts", ctx)}
+}
+
+func (_c *BudgetService_EvaluateAlerts_Call) Run(run func(ctx context.Context)) *BudgetService_EvaluateAlerts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *BudgetService_EvaluateAlerts_Call) Return(_a0 error) *BudgetService_EvaluateAlerts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BudgetService_EvaluateAlerts_Call) RunAndReturn(run func(context.Context) error) *BudgetService_EvaluateAlerts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBudget provides a mock function with given fields: ctx, id
+func (_m *BudgetService) GetBudget(ctx context.Context, id int) (models.Budget, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudget")
+	}
+
+	var r0 models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (models.Budget, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) models.Budget); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Budget)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BudgetService_GetBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBudget'
+type BudgetService_GetBudget_Call struct {
+	*mock.Call
+}
+
+// GetBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *BudgetService_Expecter) GetBudget(ctx interface{}, id interface{}) *BudgetService_GetBudget_Call {
+	return &BudgetService_GetBudget_Call{Call: _e.mock.On("GetBudget", ctx, id)}
+}
+
+func (_c *BudgetService_GetBudget_Call) Run(run func(ctx context.Context, id int)) *BudgetService_GetBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *BudgetService_GetBudget_Call) Return(_a0 models.Budget, _a1 error) *BudgetService_GetBudget_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BudgetService_GetBudget_Call) RunAndReturn(run func(context.Context, int) (models.Budget, error)) *BudgetService_GetBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Status provides a mock function with given fields: ctx, id
+func (_m *BudgetService) Status(ctx context.Context, id int) (services.BudgetStatus, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 services.BudgetStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (services.BudgetStatus, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) services.BudgetStatus); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(services.BudgetStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BudgetService_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type BudgetService_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *BudgetService_Expecter) Status(ctx interface{}, id interface{}) *BudgetService_Status_Call {
+	return &BudgetService_Status_Call{Call: _e.mock.On("Status", ctx, id)}
+}
+
+func (_c *BudgetService_Status_Call) Run(run func(ctx context.Context, id int)) *BudgetService_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *BudgetService_Status_Call) Return(_a0 services.BudgetStatus, _a1 error) *BudgetService_Status_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *BudgetService_Status_Call) RunAndReturn(run func(context.Context, int) (services.BudgetStatus, error)) *BudgetService_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBudgetService creates a new instance of BudgetService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBudgetService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BudgetService {
+	mock := &BudgetService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,322 @@
+// Code generated by mockery v2.53.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sunr3d/subscription-aggregator/models"
+
+	time "time"
+)
+
+// Budgets is an autogenerated mock type for the Budgets type
+type Budgets struct {
+	mock.Mock
+}
+
+type Budgets_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Budgets) EXPECT() *Budgets_Expecter {
+	return &Budgets_Expecter{mock: &_m.Mock}
+}
+
+// ClaimAlert provides a mock function with given fields: ctx, budgetID, periodStart, threshold
+func (_m *Budgets) ClaimAlert(ctx context.Context, budgetID int, periodStart time.Time, threshold int) (bool, error) {
+	ret := _m.Called(ctx, budgetID, periodStart, threshold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimAlert")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, int) (bool, error)); ok {
+		return rf(ctx, budgetID, periodStart, threshold)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, int) bool); ok {
+		r0 = rf(ctx, budgetID, periodStart, threshold)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, int) error); ok {
+		r1 = rf(ctx, budgetID, periodStart, threshold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Budgets_ClaimAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimAlert'
+type Budgets_ClaimAlert_Call struct {
+	*mock.Call
+}
+
+// ClaimAlert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - budgetID int
+//   - periodStart time.Time
+//   - threshold int
+func (_e *Budgets_Expecter) ClaimAlert(ctx interface{}, budgetID interface{}, periodStart interface{}, threshold interface{}) *Budgets_ClaimAlert_Call {
+	return &Budgets_ClaimAlert_Call{Call: _e.mock.On("ClaimAlert", ctx, budgetID, periodStart, threshold)}
+}
+
+func (_c *Budgets_ClaimAlert_Call) Run(run func(ctx context.Context, budgetID int, periodStart time.Time, threshold int)) *Budgets_ClaimAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Budgets_ClaimAlert_Call) Return(_a0 bool, _a1 error) *Budgets_ClaimAlert_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Budgets_ClaimAlert_Call) RunAndReturn(run func(context.Context, int, time.Time, int) (bool, error)) *Budgets_ClaimAlert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBudget provides a mock function with given fields: ctx, data
+func (_m *Budgets) CreateBudget(ctx context.Context, data models.Budget) (int, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBudget")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Budget) (int, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Budget) int); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Budget) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Budgets_CreateBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBudget'
+type Budgets_CreateBudget_Call struct {
+	*mock.Call
+}
+
+// CreateBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data models.Budget
+func (_e *Budgets_Expecter) CreateBudget(ctx interface{}, data interface{}) *Budgets_CreateBudget_Call {
+	return &Budgets_CreateBudget_Call{Call: _e.mock.On("CreateBudget", ctx, data)}
+}
+
+func (_c *Budgets_CreateBudget_Call) Run(run func(ctx context.Context, data models.Budget)) *Budgets_CreateBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Budget))
+	})
+	return _c
+}
+
+func (_c *Budgets_CreateBudget_Call) Return(_a0 int, _a1 error) *Budgets_CreateBudget_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Budgets_CreateBudget_Call) RunAndReturn(run func(context.Context, models.Budget) (int, error)) *Budgets_CreateBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBudget provides a mock function with given fields: ctx, id
+func (_m *Budgets) GetBudget(ctx context.Context, id int) (models.Budget, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBudget")
+	}
+
+	var r0 models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (models.Budget, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) models.Budget); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Budget)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Budgets_GetBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBudget'
+type Budgets_GetBudget_Call struct {
+	*mock.Call
+}
+
+// GetBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *Budgets_Expecter) GetBudget(ctx interface{}, id interface{}) *Budgets_GetBudget_Call {
+	return &Budgets_GetBudget_Call{Call: _e.mock.On("GetBudget", ctx, id)}
+}
+
+func (_c *Budgets_GetBudget_Call) Run(run func(ctx context.Context, id int)) *Budgets_GetBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Budgets_GetBudget_Call) Return(_a0 models.Budget, _a1 error) *Budgets_GetBudget_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Budgets_GetBudget_Call) RunAndReturn(run func(context.Context, int) (models.Budget, error)) *Budgets_GetBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBudgets provides a mock function with given fields: ctx, afterID, limit
+func (_m *Budgets) ListBudgets(ctx context.Context, afterID int, limit int) ([]models.Budget, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBudgets")
+	}
+
+	var r0 []models.Budget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]models.Budget, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.Budget); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Budget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Budgets_ListBudgets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBudgets'
+type Budgets_ListBudgets_Call struct {
+	*mock.Call
+}
+
+// ListBudgets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int
+//   - limit int
+func (_e *Budgets_Expecter) ListBudgets(ctx interface{}, afterID interface{}, limit interface{}) *Budgets_ListBudgets_Call {
+	return &Budgets_ListBudgets_Call{Call: _e.mock.On("ListBudgets", ctx, afterID, limit)}
+}
+
+func (_c *Budgets_ListBudgets_Call) Run(run func(ctx context.Context, afterID int, limit int)) *Budgets_ListBudgets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Budgets_ListBudgets_Call) Return(_a0 []models.Budget, _a1 error) *Budgets_ListBudgets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Budgets_ListBudgets_Call) RunAndReturn(run func(context.Context, int, int) ([]models.Budget, error)) *Budgets_ListBudgets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseAlert provides a mock function with given fields: ctx, budgetID, periodStart, threshold
+func (_m *Budgets) ReleaseAlert(ctx context.Context, budgetID int, periodStart time.Time, threshold int) error {
+	ret := _m.Called(ctx, budgetID, periodStart, threshold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseAlert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, int) error); ok {
+		r0 = rf(ctx, budgetID, periodStart, threshold)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Budgets_ReleaseAlert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseAlert'
+type Budgets_ReleaseAlert_Call struct {
+	*mock.Call
+}
+
+// ReleaseAlert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - budgetID int
+//   - periodStart time.Time
+//   - threshold int
+func (_e *Budgets_Expecter) ReleaseAlert(ctx interface{}, budgetID interface{}, periodStart interface{}, threshold interface{}) *Budgets_ReleaseAlert_Call {
+	return &Budgets_ReleaseAlert_Call{Call: _e.mock.On("ReleaseAlert", ctx, budgetID, periodStart, threshold)}
+}
+
+func (_c *Budgets_ReleaseAlert_Call) Run(run func(ctx context.Context, budgetID int, periodStart time.Time, threshold int)) *Budgets_ReleaseAlert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *Budgets_ReleaseAlert_Call) Return(_a0 error) *Budgets_ReleaseAlert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Budgets_ReleaseAlert_Call) RunAndReturn(run func(context.Context, int, time.Time, int) error) *Budgets_ReleaseAlert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewBudgets creates a new instance of Budgets. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBudgets(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Budgets {
+	mock := &Budgets{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
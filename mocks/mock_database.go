@@ -9,6 +9,8 @@ import (
 	infra "github.com/sunr3d/subscription-aggregator/internal/interfaces/infra"
 
 	models "github.com/sunr3d/subscription-aggregator/models"
+
+	time "time"
 )
 
 // Database is an autogenerated mock type for the Database type
@@ -24,6 +26,102 @@ func (_m *Database) EXPECT() *Database_Expecter {
 	return &Database_Expecter{mock: &_m.Mock}
 }
 
+// AddPause provides a mock function with given fields: ctx, subscriptionID, data
+func (_m *Database) AddPause(ctx context.Context, subscriptionID int, data models.Pause) error {
+	ret := _m.Called(ctx, subscriptionID, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.Pause) error); ok {
+		r0 = rf(ctx, subscriptionID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_AddPause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPause'
+type Database_AddPause_Call struct {
+	*mock.Call
+}
+
+// AddPause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+//   - data models.Pause
+func (_e *Database_Expecter) AddPause(ctx interface{}, subscriptionID interface{}, data interface{}) *Database_AddPause_Call {
+	return &Database_AddPause_Call{Call: _e.mock.On("AddPause", ctx, subscriptionID, data)}
+}
+
+func (_c *Database_AddPause_Call) Run(run func(ctx context.Context, subscriptionID int, data models.Pause)) *Database_AddPause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(models.Pause))
+	})
+	return _c
+}
+
+func (_c *Database_AddPause_Call) Return(_a0 error) *Database_AddPause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_AddPause_Call) RunAndReturn(run func(context.Context, int, models.Pause) error) *Database_AddPause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClosePause provides a mock function with given fields: ctx, subscriptionID, lastPaused
+func (_m *Database) ClosePause(ctx context.Context, subscriptionID int, lastPaused time.Time) error {
+	ret := _m.Called(ctx, subscriptionID, lastPaused)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClosePause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, subscriptionID, lastPaused)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_ClosePause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClosePause'
+type Database_ClosePause_Call struct {
+	*mock.Call
+}
+
+// ClosePause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+//   - lastPaused time.Time
+func (_e *Database_Expecter) ClosePause(ctx interface{}, subscriptionID interface{}, lastPaused interface{}) *Database_ClosePause_Call {
+	return &Database_ClosePause_Call{Call: _e.mock.On("ClosePause", ctx, subscriptionID, lastPaused)}
+}
+
+func (_c *Database_ClosePause_Call) Run(run func(ctx context.Context, subscriptionID int, lastPaused time.Time)) *Database_ClosePause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_ClosePause_Call) Return(_a0 error) *Database_ClosePause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_ClosePause_Call) RunAndReturn(run func(context.Context, int, time.Time) error) *Database_ClosePause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, data
 func (_m *Database) Create(ctx context.Context, data models.Subscription) (int, error) {
 	ret := _m.Called(ctx, data)
@@ -244,6 +342,198 @@ func (_c *Database_List_Call) RunAndReturn(run func(context.Context, infra.ListF
 	return _c
 }
 
+// RemoveParticipant provides a mock function with given fields: ctx, subscriptionID, userID
+func (_m *Database) RemoveParticipant(ctx context.Context, subscriptionID int, userID string) error {
+	ret := _m.Called(ctx, subscriptionID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveParticipant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, subscriptionID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_RemoveParticipant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveParticipant'
+type Database_RemoveParticipant_Call struct {
+	*mock.Call
+}
+
+// RemoveParticipant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+//   - userID string
+func (_e *Database_Expecter) RemoveParticipant(ctx interface{}, subscriptionID interface{}, userID interface{}) *Database_RemoveParticipant_Call {
+	return &Database_RemoveParticipant_Call{Call: _e.mock.On("RemoveParticipant", ctx, subscriptionID, userID)}
+}
+
+func (_c *Database_RemoveParticipant_Call) Run(run func(ctx context.Context, subscriptionID int, userID string)) *Database_RemoveParticipant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Database_RemoveParticipant_Call) Return(_a0 error) *Database_RemoveParticipant_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_RemoveParticipant_Call) RunAndReturn(run func(context.Context, int, string) error) *Database_RemoveParticipant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemovePriceChange provides a mock function with given fields: ctx, subscriptionID, effectiveFrom
+func (_m *Database) RemovePriceChange(ctx context.Context, subscriptionID int, effectiveFrom time.Time) error {
+	ret := _m.Called(ctx, subscriptionID, effectiveFrom)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePriceChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, subscriptionID, effectiveFrom)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_RemovePriceChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePriceChange'
+type Database_RemovePriceChange_Call struct {
+	*mock.Call
+}
+
+// RemovePriceChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+//   - effectiveFrom time.Time
+func (_e *Database_Expecter) RemovePriceChange(ctx interface{}, subscriptionID interface{}, effectiveFrom interface{}) *Database_RemovePriceChange_Call {
+	return &Database_RemovePriceChange_Call{Call: _e.mock.On("RemovePriceChange", ctx, subscriptionID, effectiveFrom)}
+}
+
+func (_c *Database_RemovePriceChange_Call) Run(run func(ctx context.Context, subscriptionID int, effectiveFrom time.Time)) *Database_RemovePriceChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_RemovePriceChange_Call) Return(_a0 error) *Database_RemovePriceChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_RemovePriceChange_Call) RunAndReturn(run func(context.Context, int, time.Time) error) *Database_RemovePriceChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetParticipant provides a mock function with given fields: ctx, subscriptionID, data
+func (_m *Database) SetParticipant(ctx context.Context, subscriptionID int, data models.Participant) error {
+	ret := _m.Called(ctx, subscriptionID, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetParticipant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.Participant) error); ok {
+		r0 = rf(ctx, subscriptionID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SetParticipant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetParticipant'
+type Database_SetParticipant_Call struct {
+	*mock.Call
+}
+
+// SetParticipant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+//   - data models.Participant
+func (_e *Database_Expecter) SetParticipant(ctx interface{}, subscriptionID interface{}, data interface{}) *Database_SetParticipant_Call {
+	return &Database_SetParticipant_Call{Call: _e.mock.On("SetParticipant", ctx, subscriptionID, data)}
+}
+
+func (_c *Database_SetParticipant_Call) Run(run func(ctx context.Context, subscriptionID int, data models.Participant)) *Database_SetParticipant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(models.Participant))
+	})
+	return _c
+}
+
+func (_c *Database_SetParticipant_Call) Return(_a0 error) *Database_SetParticipant_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SetParticipant_Call) RunAndReturn(run func(context.Context, int, models.Participant) error) *Database_SetParticipant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPriceChange provides a mock function with given fields: ctx, subscriptionID, data
+func (_m *Database) SetPriceChange(ctx context.Context, subscriptionID int, data models.PriceChange) error {
+	ret := _m.Called(ctx, subscriptionID, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPriceChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.PriceChange) error); ok {
+		r0 = rf(ctx, subscriptionID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SetPriceChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPriceChange'
+type Database_SetPriceChange_Call struct {
+	*mock.Call
+}
+
+// SetPriceChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int
+//   - data models.PriceChange
+func (_e *Database_Expecter) SetPriceChange(ctx interface{}, subscriptionID interface{}, data interface{}) *Database_SetPriceChange_Call {
+	return &Database_SetPriceChange_Call{Call: _e.mock.On("SetPriceChange", ctx, subscriptionID, data)}
+}
+
+func (_c *Database_SetPriceChange_Call) Run(run func(ctx context.Context, subscriptionID int, data models.PriceChange)) *Database_SetPriceChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(models.PriceChange))
+	})
+	return _c
+}
+
+func (_c *Database_SetPriceChange_Call) Return(_a0 error) *Database_SetPriceChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SetPriceChange_Call) RunAndReturn(run func(context.Context, int, models.PriceChange) error) *Database_SetPriceChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, data
 func (_m *Database) Update(ctx context.Context, data models.Subscription) error {
 	ret := _m.Called(ctx, data)
@@ -0,0 +1,128 @@
+// Code generated by mockery v2.53.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// HealthChecker is an autogenerated mock type for the HealthChecker type
+type HealthChecker struct {
+	mock.Mock
+}
+
+type HealthChecker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *HealthChecker) EXPECT() *HealthChecker_Expecter {
+	return &HealthChecker_Expecter{mock: &_m.Mock}
+}
+
+// CheckMigrations provides a mock function with given fields: ctx
+func (_m *HealthChecker) CheckMigrations(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckMigrations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthChecker_CheckMigrations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckMigrations'
+type HealthChecker_CheckMigrations_Call struct {
+	*mock.Call
+}
+
+// CheckMigrations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HealthChecker_Expecter) CheckMigrations(ctx interface{}) *HealthChecker_CheckMigrations_Call {
+	return &HealthChecker_CheckMigrations_Call{Call: _e.mock.On("CheckMigrations", ctx)}
+}
+
+func (_c *HealthChecker_CheckMigrations_Call) Run(run func(ctx context.Context)) *HealthChecker_CheckMigrations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HealthChecker_CheckMigrations_Call) Return(_a0 error) *HealthChecker_CheckMigrations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthChecker_CheckMigrations_Call) RunAndReturn(run func(context.Context) error) *HealthChecker_CheckMigrations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function with given fields: ctx
+func (_m *HealthChecker) Ping(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// HealthChecker_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type HealthChecker_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *HealthChecker_Expecter) Ping(ctx interface{}) *HealthChecker_Ping_Call {
+	return &HealthChecker_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *HealthChecker_Ping_Call) Run(run func(ctx context.Context)) *HealthChecker_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *HealthChecker_Ping_Call) Return(_a0 error) *HealthChecker_Ping_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthChecker_Ping_Call) RunAndReturn(run func(context.Context) error) *HealthChecker_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewHealthChecker creates a new instance of HealthChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewHealthChecker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *HealthChecker {
+	mock := &HealthChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,259 @@
+// Code generated by mockery v2.53.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sunr3d/subscription-aggregator/models"
+
+	time "time"
+)
+
+// IdempotencyStore is an autogenerated mock type for the IdempotencyStore type
+type IdempotencyStore struct {
+	mock.Mock
+}
+
+type IdempotencyStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IdempotencyStore) EXPECT() *IdempotencyStore_Expecter {
+	return &IdempotencyStore_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: ctx, key, statusCode, contentType, body
+func (_m *IdempotencyStore) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	ret := _m.Called(ctx, key, statusCode, contentType, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string, []byte) error); ok {
+		r0 = rf(ctx, key, statusCode, contentType, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyStore_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type IdempotencyStore_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - statusCode int
+//   - contentType string
+//   - body []byte
+func (_e *IdempotencyStore_Expecter) Complete(ctx interface{}, key interface{}, statusCode interface{}, contentType interface{}, body interface{}) *IdempotencyStore_Complete_Call {
+	return &IdempotencyStore_Complete_Call{Call: _e.mock.On("Complete", ctx, key, statusCode, contentType, body)}
+}
+
+func (_c *IdempotencyStore_Complete_Call) Run(run func(ctx context.Context, key string, statusCode int, contentType string, body []byte)) *IdempotencyStore_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(string), args[4].([]byte))
+	})
+	return _c
+}
+
+func (_c *IdempotencyStore_Complete_Call) Return(_a0 error) *IdempotencyStore_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyStore_Complete_Call) RunAndReturn(run func(context.Context, string, int, string, []byte) error) *IdempotencyStore_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpired provides a mock function with given fields: ctx
+func (_m *IdempotencyStore) PurgeExpired(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IdempotencyStore_PurgeExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpired'
+type IdempotencyStore_PurgeExpired_Call struct {
+	*mock.Call
+}
+
+// PurgeExpired is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *IdempotencyStore_Expecter) PurgeExpired(ctx interface{}) *IdempotencyStore_PurgeExpired_Call {
+	return &IdempotencyStore_PurgeExpired_Call{Call: _e.mock.On("PurgeExpired", ctx)}
+}
+
+func (_c *IdempotencyStore_PurgeExpired_Call) Run(run func(ctx context.Context)) *IdempotencyStore_PurgeExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IdempotencyStore_PurgeExpired_Call) Return(_a0 int64, _a1 error) *IdempotencyStore_PurgeExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IdempotencyStore_PurgeExpired_Call) RunAndReturn(run func(context.Context) (int64, error)) *IdempotencyStore_PurgeExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, key
+func (_m *IdempotencyStore) Release(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IdempotencyStore_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type IdempotencyStore_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *IdempotencyStore_Expecter) Release(ctx interface{}, key interface{}) *IdempotencyStore_Release_Call {
+	return &IdempotencyStore_Release_Call{Call: _e.mock.On("Release", ctx, key)}
+}
+
+func (_c *IdempotencyStore_Release_Call) Run(run func(ctx context.Context, key string)) *IdempotencyStore_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IdempotencyStore_Release_Call) Return(_a0 error) *IdempotencyStore_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IdempotencyStore_Release_Call) RunAndReturn(run func(context.Context, string) error) *IdempotencyStore_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, key, requestHash, ttl
+func (_m *IdempotencyStore) Reserve(ctx context.Context, key string, requestHash string, ttl time.Duration) (models.IdempotencyRecord, bool, error) {
+	ret := _m.Called(ctx, key, requestHash, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 models.IdempotencyRecord
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (models.IdempotencyRecord, bool, error)); ok {
+		return rf(ctx, key, requestHash, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) models.IdempotencyRecord); ok {
+		r0 = rf(ctx, key, requestHash, ttl)
+	} else {
+		r0 = ret.Get(0).(models.IdempotencyRecord)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) bool); ok {
+		r1 = rf(ctx, key, requestHash, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, time.Duration) error); ok {
+		r2 = rf(ctx, key, requestHash, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IdempotencyStore_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type IdempotencyStore_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - requestHash string
+//   - ttl time.Duration
+func (_e *IdempotencyStore_Expecter) Reserve(ctx interface{}, key interface{}, requestHash interface{}, ttl interface{}) *IdempotencyStore_Reserve_Call {
+	return &IdempotencyStore_Reserve_Call{Call: _e.mock.On("Reserve", ctx, key, requestHash, ttl)}
+}
+
+func (_c *IdempotencyStore_Reserve_Call) Run(run func(ctx context.Context, key string, requestHash string, ttl time.Duration)) *IdempotencyStore_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *IdempotencyStore_Reserve_Call) Return(rec models.IdempotencyRecord, reserved bool, err error) *IdempotencyStore_Reserve_Call {
+	_c.Call.Return(rec, reserved, err)
+	return _c
+}
+
+func (_c *IdempotencyStore_Reserve_Call) RunAndReturn(run func(context.Context, string, string, time.Duration) (models.IdempotencyRecord, bool, error)) *IdempotencyStore_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIdempotencyStore creates a new instance of IdempotencyStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIdempotencyStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IdempotencyStore {
+	mock := &IdempotencyStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.53.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/sunr3d/subscription-aggregator/models"
+)
+
+// Notifier is an autogenerated mock type for the Notifier type
+type Notifier struct {
+	mock.Mock
+}
+
+type Notifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Notifier) EXPECT() *Notifier_Expecter {
+	return &Notifier_Expecter{mock: &_m.Mock}
+}
+
+// NotifyBudget provides a mock function with given fields: ctx, alert
+func (_m *Notifier) NotifyBudget(ctx context.Context, alert models.BudgetAlert) error {
+	ret := _m.Called(ctx, alert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyBudget")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.BudgetAlert) error); ok {
+		r0 = rf(ctx, alert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Notifier_NotifyBudget_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyBudget'
+type Notifier_NotifyBudget_Call struct {
+	*mock.Call
+}
+
+// NotifyBudget is a helper method to define mock.On call
+//   - ctx context.Context
+//   - alert models.BudgetAlert
+func (_e *Notifier_Expecter) NotifyBudget(ctx interface{}, alert interface{}) *Notifier_NotifyBudget_Call {
+	return &Notifier_NotifyBudget_Call{Call: _e.mock.On("NotifyBudget", ctx, alert)}
+}
+
+func (_c *Notifier_NotifyBudget_Call) Run(run func(ctx context.Context, alert models.BudgetAlert)) *Notifier_NotifyBudget_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.BudgetAlert))
+	})
+	return _c
+}
+
+func (_c *Notifier_NotifyBudget_Call) Return(_a0 error) *Notifier_NotifyBudget_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Notifier_NotifyBudget_Call) RunAndReturn(run func(context.Context, models.BudgetAlert) error) *Notifier_NotifyBudget_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifier creates a new instance of Notifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Notifier {
+	mock := &Notifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
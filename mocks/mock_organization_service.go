@@ -0,0 +1,478 @@
+// Code generated by mockery v2.53.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	models "github.com/sunr3d/subscription-aggregator/models"
+)
+
+// OrganizationService is an autogenerated mock type for the OrganizationService type
+type OrganizationService struct {
+	mock.Mock
+}
+
+type OrganizationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OrganizationService) EXPECT() *OrganizationService_Expecter {
+	return &OrganizationService_Expecter{mock: &_m.Mock}
+}
+
+// CreateOrganization provides a mock function with given fields: ctx, data
+func (_m *OrganizationService) CreateOrganization(ctx context.Context, data models.Organization) (int, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrganization")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Organization) (int, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Organization) int); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Organization) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_CreateOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrganization'
+type OrganizationService_CreateOrganization_Call struct {
+	*mock.Call
+}
+
+// CreateOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data models.Organization
+func (_e *OrganizationService_Expecter) CreateOrganization(ctx interface{}, data interface{}) *OrganizationService_CreateOrganization_Call {
+	return &OrganizationService_CreateOrganization_Call{Call: _e.mock.On("CreateOrganization", ctx, data)}
+}
+
+func (_c *OrganizationService_CreateOrganization_Call) Run(run func(ctx context.Context, data models.Organization)) *OrganizationService_CreateOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Organization))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_CreateOrganization_Call) Return(_a0 int, _a1 error) *OrganizationService_CreateOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_CreateOrganization_Call) RunAndReturn(run func(context.Context, models.Organization) (int, error)) *OrganizationService_CreateOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTeam provides a mock function with given fields: ctx, data
+func (_m *OrganizationService) CreateTeam(ctx context.Context, data models.Team) (int, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTeam")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Team) (int, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Team) int); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Team) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_CreateTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTeam'
+type OrganizationService_CreateTeam_Call struct {
+	*mock.Call
+}
+
+// CreateTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data models.Team
+func (_e *OrganizationService_Expecter) CreateTeam(ctx interface{}, data interface{}) *OrganizationService_CreateTeam_Call {
+	return &OrganizationService_CreateTeam_Call{Call: _e.mock.On("CreateTeam", ctx, data)}
+}
+
+func (_c *OrganizationService_CreateTeam_Call) Run(run func(ctx context.Context, data models.Team)) *OrganizationService_CreateTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Team))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_CreateTeam_Call) Return(_a0 int, _a1 error) *OrganizationService_CreateTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_CreateTeam_Call) RunAndReturn(run func(context.Context, models.Team) (int, error)) *OrganizationService_CreateTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrganization provides a mock function with given fields: ctx, id
+func (_m *OrganizationService) GetOrganization(ctx context.Context, id int) (models.Organization, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrganization")
+	}
+
+	var r0 models.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (models.Organization, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) models.Organization); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Organization)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_GetOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrganization'
+type OrganizationService_GetOrganization_Call struct {
+	*mock.Call
+}
+
+// GetOrganization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *OrganizationService_Expecter) GetOrganization(ctx interface{}, id interface{}) *OrganizationService_GetOrganization_Call {
+	return &OrganizationService_GetOrganization_Call{Call: _e.mock.On("GetOrganization", ctx, id)}
+}
+
+func (_c *OrganizationService_GetOrganization_Call) Run(run func(ctx context.Context, id int)) *OrganizationService_GetOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_GetOrganization_Call) Return(_a0 models.Organization, _a1 error) *OrganizationService_GetOrganization_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_GetOrganization_Call) RunAndReturn(run func(context.Context, int) (models.Organization, error)) *OrganizationService_GetOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeam provides a mock function with given fields: ctx, id
+func (_m *OrganizationService) GetTeam(ctx context.Context, id int) (models.Team, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeam")
+	}
+
+	var r0 models.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (models.Team, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) models.Team); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Team)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_GetTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeam'
+type OrganizationService_GetTeam_Call struct {
+	*mock.Call
+}
+
+// GetTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *OrganizationService_Expecter) GetTeam(ctx interface{}, id interface{}) *OrganizationService_GetTeam_Call {
+	return &OrganizationService_GetTeam_Call{Call: _e.mock.On("GetTeam", ctx, id)}
+}
+
+func (_c *OrganizationService_GetTeam_Call) Run(run func(ctx context.Context, id int)) *OrganizationService_GetTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_GetTeam_Call) Return(_a0 models.Team, _a1 error) *OrganizationService_GetTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_GetTeam_Call) RunAndReturn(run func(context.Context, int) (models.Team, error)) *OrganizationService_GetTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMembers provides a mock function with given fields: ctx, teamID
+func (_m *OrganizationService) ListMembers(ctx context.Context, teamID int) ([]models.TeamMember, error) {
+	ret := _m.Called(ctx, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMembers")
+	}
+
+	var r0 []models.TeamMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]models.TeamMember, error)); ok {
+		return rf(ctx, teamID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.TeamMember); ok {
+		r0 = rf(ctx, teamID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, teamID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_ListMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMembers'
+type OrganizationService_ListMembers_Call struct {
+	*mock.Call
+}
+
+// ListMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamID int
+func (_e *OrganizationService_Expecter) ListMembers(ctx interface{}, teamID interface{}) *OrganizationService_ListMembers_Call {
+	return &OrganizationService_ListMembers_Call{Call: _e.mock.On("ListMembers", ctx, teamID)}
+}
+
+func (_c *OrganizationService_ListMembers_Call) Run(run func(ctx context.Context, teamID int)) *OrganizationService_ListMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_ListMembers_Call) Return(_a0 []models.TeamMember, _a1 error) *OrganizationService_ListMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_ListMembers_Call) RunAndReturn(run func(context.Context, int) ([]models.TeamMember, error)) *OrganizationService_ListMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTeams provides a mock function with given fields: ctx, organizationID
+func (_m *OrganizationService) ListTeams(ctx context.Context, organizationID int) ([]models.Team, error) {
+	ret := _m.Called(ctx, organizationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeams")
+	}
+
+	var r0 []models.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]models.Team, error)); ok {
+		return rf(ctx, organizationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.Team); ok {
+		r0 = rf(ctx, organizationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, organizationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OrganizationService_ListTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTeams'
+type OrganizationService_ListTeams_Call struct {
+	*mock.Call
+}
+
+// ListTeams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - organizationID int
+func (_e *OrganizationService_Expecter) ListTeams(ctx interface{}, organizationID interface{}) *OrganizationService_ListTeams_Call {
+	return &OrganizationService_ListTeams_Call{Call: _e.mock.On("ListTeams", ctx, organizationID)}
+}
+
+func (_c *OrganizationService_ListTeams_Call) Run(run func(ctx context.Context, organizationID int)) *OrganizationService_ListTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_ListTeams_Call) Return(_a0 []models.Team, _a1 error) *OrganizationService_ListTeams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OrganizationService_ListTeams_Call) RunAndReturn(run func(context.Context, int) ([]models.Team, error)) *OrganizationService_ListTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function with given fields: ctx, teamID, userID
+func (_m *OrganizationService) RemoveMember(ctx context.Context, teamID int, userID string) error {
+	ret := _m.Called(ctx, teamID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, teamID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OrganizationService_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type OrganizationService_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamID int
+//   - userID string
+func (_e *OrganizationService_Expecter) RemoveMember(ctx interface{}, teamID interface{}, userID interface{}) *OrganizationService_RemoveMember_Call {
+	return &OrganizationService_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, teamID, userID)}
+}
+
+func (_c *OrganizationService_RemoveMember_Call) Run(run func(ctx context.Context, teamID int, userID string)) *OrganizationService_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_RemoveMember_Call) Return(_a0 error) *OrganizationService_RemoveMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OrganizationService_RemoveMember_Call) RunAndReturn(run func(context.Context, int, string) error) *OrganizationService_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMember provides a mock function with given fields: ctx, data
+func (_m *OrganizationService) SetMember(ctx context.Context, data models.TeamMember) error {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMember")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.TeamMember) error); ok {
+		r0 = rf(ctx, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OrganizationService_SetMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMember'
+type OrganizationService_SetMember_Call struct {
+	*mock.Call
+}
+
+// SetMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data models.TeamMember
+func (_e *OrganizationService_Expecter) SetMember(ctx interface{}, data interface{}) *OrganizationService_SetMember_Call {
+	return &OrganizationService_SetMember_Call{Call: _e.mock.On("SetMember", ctx, data)}
+}
+
+func (_c *OrganizationService_SetMember_Call) Run(run func(ctx context.Context, data models.TeamMember)) *OrganizationService_SetMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.TeamMember))
+	})
+	return _c
+}
+
+func (_c *OrganizationService_SetMember_Call) Return(_a0 error) *OrganizationService_SetMember_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OrganizationService_SetMember_Call) RunAndReturn(run func(context.Context, models.TeamMember) error) *OrganizationService_SetMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOrganizationService creates a new instance of OrganizationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrganizationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrganizationService {
+	mock := &OrganizationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
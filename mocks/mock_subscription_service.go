@@ -9,6 +9,8 @@ import (
 	models "github.com/sunr3d/subscription-aggregator/models"
 
 	services "github.com/sunr3d/subscription-aggregator/internal/interfaces/services"
+
+	time "time"
 )
 
 // SubscriptionService is an autogenerated mock type for the SubscriptionService type
@@ -128,6 +130,185 @@ func (_c *SubscriptionService_Delete_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// Duplicates provides a mock function with given fields: ctx, filter
+func (_m *SubscriptionService) Duplicates(ctx context.Context, filter services.ListFilter) ([]services.DuplicateGroup, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Duplicates")
+	}
+
+	var r0 []services.DuplicateGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, services.ListFilter) ([]services.DuplicateGroup, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, services.ListFilter) []services.DuplicateGroup); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.DuplicateGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, services.ListFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscriptionService_Duplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Duplicates'
+type SubscriptionService_Duplicates_Call struct {
+	*mock.Call
+}
+
+// Duplicates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter services.ListFilter
+func (_e *SubscriptionService_Expecter) Duplicates(ctx interface{}, filter interface{}) *SubscriptionService_Duplicates_Call {
+	return &SubscriptionService_Duplicates_Call{Call: _e.mock.On("Duplicates", ctx, filter)}
+}
+
+func (_c *SubscriptionService_Duplicates_Call) Run(run func(ctx context.Context, filter services.ListFilter)) *SubscriptionService_Duplicates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(services.ListFilter))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_Duplicates_Call) Return(_a0 []services.DuplicateGroup, _a1 error) *SubscriptionService_Duplicates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SubscriptionService_Duplicates_Call) RunAndReturn(run func(context.Context, services.ListFilter) ([]services.DuplicateGroup, error)) *SubscriptionService_Duplicates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOverlaps provides a mock function with given fields: ctx, data
+func (_m *SubscriptionService) FindOverlaps(ctx context.Context, data models.Subscription) ([]models.Subscription, error) {
+	ret := _m.Called(ctx, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOverlaps")
+	}
+
+	var r0 []models.Subscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Subscription) ([]models.Subscription, error)); ok {
+		return rf(ctx, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Subscription) []models.Subscription); ok {
+		r0 = rf(ctx, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Subscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Subscription) error); ok {
+		r1 = rf(ctx, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscriptionService_FindOverlaps_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOverlaps'
+type SubscriptionService_FindOverlaps_Call struct {
+	*mock.Call
+}
+
+// FindOverlaps is a helper method to define mock.On call
+//   - ctx context.Context
+//   - data models.Subscription
+func (_e *SubscriptionService_Expecter) FindOverlaps(ctx interface{}, data interface{}) *SubscriptionService_FindOverlaps_Call {
+	return &SubscriptionService_FindOverlaps_Call{Call: _e.mock.On("FindOverlaps", ctx, data)}
+}
+
+func (_c *SubscriptionService_FindOverlaps_Call) Run(run func(ctx context.Context, data models.Subscription)) *SubscriptionService_FindOverlaps_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Subscription))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_FindOverlaps_Call) Return(_a0 []models.Subscription, _a1 error) *SubscriptionService_FindOverlaps_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SubscriptionService_FindOverlaps_Call) RunAndReturn(run func(context.Context, models.Subscription) ([]models.Subscription, error)) *SubscriptionService_FindOverlaps_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Forecast provides a mock function with given fields: ctx, from, months, filter
+func (_m *SubscriptionService) Forecast(ctx context.Context, from time.Time, months int, filter services.ListFilter) ([]services.MonthCost, error) {
+	ret := _m.Called(ctx, from, months, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Forecast")
+	}
+
+	var r0 []services.MonthCost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, services.ListFilter) ([]services.MonthCost, error)); ok {
+		return rf(ctx, from, months, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, services.ListFilter) []services.MonthCost); ok {
+		r0 = rf(ctx, from, months, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.MonthCost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int, services.ListFilter) error); ok {
+		r1 = rf(ctx, from, months, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscriptionService_Forecast_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Forecast'
+type SubscriptionService_Forecast_Call struct {
+	*mock.Call
+}
+
+// Forecast is a helper method to define mock.On call
+//   - ctx context.Context
+//   - from time.Time
+//   - months int
+//   - filter services.ListFilter
+func (_e *SubscriptionService_Expecter) Forecast(ctx interface{}, from interface{}, months interface{}, filter interface{}) *SubscriptionService_Forecast_Call {
+	return &SubscriptionService_Forecast_Call{Call: _e.mock.On("Forecast", ctx, from, months, filter)}
+}
+
+func (_c *SubscriptionService_Forecast_Call) Run(run func(ctx context.Context, from time.Time, months int, filter services.ListFilter)) *SubscriptionService_Forecast_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int), args[3].(services.ListFilter))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_Forecast_Call) Return(_a0 []services.MonthCost, _a1 error) *SubscriptionService_Forecast_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SubscriptionService_Forecast_Call) RunAndReturn(run func(context.Context, time.Time, int, services.ListFilter) ([]services.MonthCost, error)) *SubscriptionService_Forecast_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *SubscriptionService) GetByID(ctx context.Context, id int) (models.Subscription, error) {
 	ret := _m.Called(ctx, id)
@@ -244,6 +425,414 @@ func (_c *SubscriptionService_List_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// Pause provides a mock function with given fields: ctx, id, data
+func (_m *SubscriptionService) Pause(ctx context.Context, id int, data models.Pause) error {
+	ret := _m.Called(ctx, id, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.Pause) error); ok {
+		r0 = rf(ctx, id, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscriptionService_Pause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pause'
+type SubscriptionService_Pause_Call struct {
+	*mock.Call
+}
+
+// Pause is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - data models.Pause
+func (_e *SubscriptionService_Expecter) Pause(ctx interface{}, id interface{}, data interface{}) *SubscriptionService_Pause_Call {
+	return &SubscriptionService_Pause_Call{Call: _e.mock.On("Pause", ctx, id, data)}
+}
+
+func (_c *SubscriptionService_Pause_Call) Run(run func(ctx context.Context, id int, data models.Pause)) *SubscriptionService_Pause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(models.Pause))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_Pause_Call) Return(_a0 error) *SubscriptionService_Pause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SubscriptionService_Pause_Call) RunAndReturn(run func(context.Context, int, models.Pause) error) *SubscriptionService_Pause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveParticipant provides a mock function with given fields: ctx, id, userID
+func (_m *SubscriptionService) RemoveParticipant(ctx context.Context, id int, userID string) error {
+	ret := _m.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveParticipant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
+		r0 = rf(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscriptionService_RemoveParticipant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveParticipant'
+type SubscriptionService_RemoveParticipant_Call struct {
+	*mock.Call
+}
+
+// RemoveParticipant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - userID string
+func (_e *SubscriptionService_Expecter) RemoveParticipant(ctx interface{}, id interface{}, userID interface{}) *SubscriptionService_RemoveParticipant_Call {
+	return &SubscriptionService_RemoveParticipant_Call{Call: _e.mock.On("RemoveParticipant", ctx, id, userID)}
+}
+
+func (_c *SubscriptionService_RemoveParticipant_Call) Run(run func(ctx context.Context, id int, userID string)) *SubscriptionService_RemoveParticipant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_RemoveParticipant_Call) Return(_a0 error) *SubscriptionService_RemoveParticipant_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SubscriptionService_RemoveParticipant_Call) RunAndReturn(run func(context.Context, int, string) error) *SubscriptionService_RemoveParticipant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemovePriceChange provides a mock function with given fields: ctx, id, effectiveFrom
+func (_m *SubscriptionService) RemovePriceChange(ctx context.Context, id int, effectiveFrom time.Time) error {
+	ret := _m.Called(ctx, id, effectiveFrom)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemovePriceChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, id, effectiveFrom)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscriptionService_RemovePriceChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemovePriceChange'
+type SubscriptionService_RemovePriceChange_Call struct {
+	*mock.Call
+}
+
+// RemovePriceChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - effectiveFrom time.Time
+func (_e *SubscriptionService_Expecter) RemovePriceChange(ctx interface{}, id interface{}, effectiveFrom interface{}) *SubscriptionService_RemovePriceChange_Call {
+	return &SubscriptionService_RemovePriceChange_Call{Call: _e.mock.On("RemovePriceChange", ctx, id, effectiveFrom)}
+}
+
+func (_c *SubscriptionService_RemovePriceChange_Call) Run(run func(ctx context.Context, id int, effectiveFrom time.Time)) *SubscriptionService_RemovePriceChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_RemovePriceChange_Call) Return(_a0 error) *SubscriptionService_RemovePriceChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SubscriptionService_RemovePriceChange_Call) RunAndReturn(run func(context.Context, int, time.Time) error) *SubscriptionService_RemovePriceChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resume provides a mock function with given fields: ctx, id, from
+func (_m *SubscriptionService) Resume(ctx context.Context, id int, from time.Time) error {
+	ret := _m.Called(ctx, id, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) error); ok {
+		r0 = rf(ctx, id, from)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscriptionService_Resume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resume'
+type SubscriptionService_Resume_Call struct {
+	*mock.Call
+}
+
+// Resume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - from time.Time
+func (_e *SubscriptionService_Expecter) Resume(ctx interface{}, id interface{}, from interface{}) *SubscriptionService_Resume_Call {
+	return &SubscriptionService_Resume_Call{Call: _e.mock.On("Resume", ctx, id, from)}
+}
+
+func (_c *SubscriptionService_Resume_Call) Run(run func(ctx context.Context, id int, from time.Time)) *SubscriptionService_Resume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_Resume_Call) Return(_a0 error) *SubscriptionService_Resume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SubscriptionService_Resume_Call) RunAndReturn(run func(context.Context, int, time.Time) error) *SubscriptionService_Resume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetParticipant provides a mock function with given fields: ctx, id, data
+func (_m *SubscriptionService) SetParticipant(ctx context.Context, id int, data models.Participant) error {
+	ret := _m.Called(ctx, id, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetParticipant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.Participant) error); ok {
+		r0 = rf(ctx, id, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscriptionService_SetParticipant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetParticipant'
+type SubscriptionService_SetParticipant_Call struct {
+	*mock.Call
+}
+
+// SetParticipant is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - data models.Participant
+func (_e *SubscriptionService_Expecter) SetParticipant(ctx interface{}, id interface{}, data interface{}) *SubscriptionService_SetParticipant_Call {
+	return &SubscriptionService_SetParticipant_Call{Call: _e.mock.On("SetParticipant", ctx, id, data)}
+}
+
+func (_c *SubscriptionService_SetParticipant_Call) Run(run func(ctx context.Context, id int, data models.Participant)) *SubscriptionService_SetParticipant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(models.Participant))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_SetParticipant_Call) Return(_a0 error) *SubscriptionService_SetParticipant_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SubscriptionService_SetParticipant_Call) RunAndReturn(run func(context.Context, int, models.Participant) error) *SubscriptionService_SetParticipant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPriceChange provides a mock function with given fields: ctx, id, data
+func (_m *SubscriptionService) SetPriceChange(ctx context.Context, id int, data models.PriceChange) error {
+	ret := _m.Called(ctx, id, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPriceChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.PriceChange) error); ok {
+		r0 = rf(ctx, id, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SubscriptionService_SetPriceChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPriceChange'
+type SubscriptionService_SetPriceChange_Call struct {
+	*mock.Call
+}
+
+// SetPriceChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - data models.PriceChange
+func (_e *SubscriptionService_Expecter) SetPriceChange(ctx interface{}, id interface{}, data interface{}) *SubscriptionService_SetPriceChange_Call {
+	return &SubscriptionService_SetPriceChange_Call{Call: _e.mock.On("SetPriceChange", ctx, id, data)}
+}
+
+func (_c *SubscriptionService_SetPriceChange_Call) Run(run func(ctx context.Context, id int, data models.PriceChange)) *SubscriptionService_SetPriceChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(models.PriceChange))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_SetPriceChange_Call) Return(_a0 error) *SubscriptionService_SetPriceChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SubscriptionService_SetPriceChange_Call) RunAndReturn(run func(context.Context, int, models.PriceChange) error) *SubscriptionService_SetPriceChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TotalCost provides a mock function with given fields: ctx, start, end, filter
+func (_m *SubscriptionService) TotalCost(ctx context.Context, start time.Time, end time.Time, filter services.ListFilter) (int, error) {
+	ret := _m.Called(ctx, start, end, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalCost")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, services.ListFilter) (int, error)); ok {
+		return rf(ctx, start, end, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, services.ListFilter) int); ok {
+		r0 = rf(ctx, start, end, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, services.ListFilter) error); ok {
+		r1 = rf(ctx, start, end, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscriptionService_TotalCost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalCost'
+type SubscriptionService_TotalCost_Call struct {
+	*mock.Call
+}
+
+// TotalCost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - start time.Time
+//   - end time.Time
+//   - filter services.ListFilter
+func (_e *SubscriptionService_Expecter) TotalCost(ctx interface{}, start interface{}, end interface{}, filter interface{}) *SubscriptionService_TotalCost_Call {
+	return &SubscriptionService_TotalCost_Call{Call: _e.mock.On("TotalCost", ctx, start, end, filter)}
+}
+
+func (_c *SubscriptionService_TotalCost_Call) Run(run func(ctx context.Context, start time.Time, end time.Time, filter services.ListFilter)) *SubscriptionService_TotalCost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(services.ListFilter))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_TotalCost_Call) Return(_a0 int, _a1 error) *SubscriptionService_TotalCost_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SubscriptionService_TotalCost_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, services.ListFilter) (int, error)) *SubscriptionService_TotalCost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TotalCostByTeam provides a mock function with given fields: ctx, start, end, filter
+func (_m *SubscriptionService) TotalCostByTeam(ctx context.Context, start time.Time, end time.Time, filter services.ListFilter) ([]services.TeamCost, error) {
+	ret := _m.Called(ctx, start, end, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalCostByTeam")
+	}
+
+	var r0 []services.TeamCost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, services.ListFilter) ([]services.TeamCost, error)); ok {
+		return rf(ctx, start, end, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, services.ListFilter) []services.TeamCost); ok {
+		r0 = rf(ctx, start, end, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]services.TeamCost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, services.ListFilter) error); ok {
+		r1 = rf(ctx, start, end, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscriptionService_TotalCostByTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalCostByTeam'
+type SubscriptionService_TotalCostByTeam_Call struct {
+	*mock.Call
+}
+
+// TotalCostByTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - start time.Time
+//   - end time.Time
+//   - filter services.ListFilter
+func (_e *SubscriptionService_Expecter) TotalCostByTeam(ctx interface{}, start interface{}, end interface{}, filter interface{}) *SubscriptionService_TotalCostByTeam_Call {
+	return &SubscriptionService_TotalCostByTeam_Call{Call: _e.mock.On("TotalCostByTeam", ctx, start, end, filter)}
+}
+
+func (_c *SubscriptionService_TotalCostByTeam_Call) Run(run func(ctx context.Context, start time.Time, end time.Time, filter services.ListFilter)) *SubscriptionService_TotalCostByTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(services.ListFilter))
+	})
+	return _c
+}
+
+func (_c *SubscriptionService_TotalCostByTeam_Call) Return(_a0 []services.TeamCost, _a1 error) *SubscriptionService_TotalCostByTeam_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SubscriptionService_TotalCostByTeam_Call) RunAndReturn(run func(context.Context, time.Time, time.Time, services.ListFilter) ([]services.TeamCost, error)) *SubscriptionService_TotalCostByTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, data
 func (_m *SubscriptionService) Update(ctx context.Context, data models.Subscription) error {
 	ret := _m.Called(ctx, data)
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Периоды бюджета.
+const (
+	BudgetMonthly = "monthly"
+	BudgetYearly  = "yearly"
+)
+
+// Budget - лимит расходов пользователя (UserID) или команды (TeamID) на месяц или год,
+// по всем подпискам владельца или только по категории и/или сервису.
+type Budget struct {
+	ID          int
+	UserID      *string
+	TeamID      *int
+	Period      string
+	Amount      int
+	Category    string
+	ServiceName string
+	CreatedAt   time.Time
+}
+
+// BudgetAlert - уведомление о том, что расходы за период достигли порога (в процентах) бюджета.
+type BudgetAlert struct {
+	Budget      Budget
+	Threshold   int
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Spent       int
+}
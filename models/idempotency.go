@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type IdempotencyRecord struct {
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	ExpiresAt    time.Time
+}
+
+// Completed - ответ на исходный запрос уже сохранён и может быть воспроизведён.
+func (r IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type Organization struct {
+	ID        int
+	Name      string
+	OwnerID   *string
+	CreatedAt time.Time
+}
+
+type Team struct {
+	ID             int
+	OrganizationID int
+	Name           string
+	CreatedAt      time.Time
+}
+
+// Роли участника команды: admin управляет составом команды.
+const (
+	RoleMember = "member"
+	RoleAdmin  = "admin"
+)
+
+type TeamMember struct {
+	TeamID int
+	UserID string
+	Role   string
+}
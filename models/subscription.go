@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 type Subscription struct {
 	ID          int
@@ -9,4 +12,142 @@ type Subscription struct {
 	UserID      string
 	StartDate   time.Time
 	EndDate     *time.Time
+	TeamID      *int
+	// TrialEnd - последний месяц бесплатного пробного периода (включительно); nil - без пробного периода.
+	TrialEnd *time.Time
+	// Category - категория подписки (например, "видео"); пустая - без категории.
+	Category string
+	// Participants - пользователи, между которыми делится цена; пусто - платит только владелец (UserID).
+	Participants []Participant
+	// PriceChanges - запланированные изменения цены по возрастанию EffectiveFrom; Price действует до первого из них.
+	PriceChanges []PriceChange
+	// Pauses - паузы по возрастанию Start; в приостановленные месяцы подписка не действует и не оплачивается.
+	Pauses    []Pause
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Participant - участник подписки: платит либо долю по весу (ShareWeight), либо фиксированную сумму в месяц (FixedAmount).
+type Participant struct {
+	UserID      string
+	ShareWeight *int
+	FixedAmount *int
+}
+
+// PriceChange - новая цена подписки, действующая с месяца EffectiveFrom.
+type PriceChange struct {
+	EffectiveFrom time.Time
+	Price         int
+}
+
+// Pause - приостановка подписки с месяца Start по месяц End включительно; End == nil - до возобновления.
+type Pause struct {
+	Start time.Time
+	End   *time.Time
+}
+
+// PausedAt - приостановлена ли подписка в месяце month.
+func (s Subscription) PausedAt(month time.Time) bool {
+	m := monthIndex(month)
+	for _, p := range s.Pauses {
+		if monthIndex(p.Start) <= m && (p.End == nil || monthIndex(*p.End) >= m) {
+			return true
+		}
+	}
+	return false
+}
+
+// PriceAt - цена подписки в месяце month с учётом запланированных изменений.
+func (s Subscription) PriceAt(month time.Time) int {
+	price := s.Price
+	for _, c := range s.PriceChanges {
+		if monthIndex(c.EffectiveFrom) > monthIndex(month) {
+			break
+		}
+		price = c.Price
+	}
+	return price
+}
+
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
+
+// Shares - ежемесячная доля цены каждого плательщика.
+//
+// Без участников всю цену платит владелец. Иначе сначала вычитаются фиксированные суммы, а остаток
+// делится между участниками пропорционально весам; если участников с весами нет, остаток платит владелец.
+// Если фиксированные суммы превышают цену, цена делится пропорционально им.
+// Доли целые, остаток от деления распределяется по рублю, поэтому сумма долей всегда равна цене.
+func (s Subscription) Shares() map[string]int {
+	res := make(map[string]int, len(s.Participants)+1)
+	if len(s.Participants) == 0 {
+		res[s.UserID] = s.Price
+		return res
+	}
+
+	var fixed, weighted []weight
+	fixedSum := 0
+	for _, p := range s.Participants {
+		res[p.UserID] = 0
+		switch {
+		case p.FixedAmount != nil:
+			fixed = append(fixed, weight{p.UserID, *p.FixedAmount})
+			fixedSum += *p.FixedAmount
+		case p.ShareWeight != nil:
+			weighted = append(weighted, weight{p.UserID, *p.ShareWeight})
+		}
+	}
+
+	if fixedSum >= s.Price {
+		split(res, s.Price, fixed)
+		return res
+	}
+	for _, f := range fixed {
+		res[f.userID] += f.value
+	}
+	if rest := s.Price - fixedSum; len(weighted) > 0 {
+		split(res, rest, weighted)
+	} else {
+		res[s.UserID] += rest
+	}
+	return res
+}
+
+type weight struct {
+	userID string
+	value  int
+}
+
+// split делит amount пропорционально весам методом наибольшего остатка.
+func split(dst map[string]int, amount int, weights []weight) {
+	total := 0
+	for _, w := range weights {
+		total += w.value
+	}
+	if amount <= 0 || total <= 0 {
+		return
+	}
+
+	type part struct {
+		userID string
+		rem    int
+	}
+	parts := make([]part, 0, len(weights))
+	left := amount
+	for _, w := range weights {
+		share := amount * w.value / total
+		dst[w.userID] += share
+		left -= share
+		parts = append(parts, part{w.userID, amount * w.value % total})
+	}
+	sort.SliceStable(parts, func(i, j int) bool {
+		if parts[i].rem != parts[j].rem {
+			return parts[i].rem > parts[j].rem
+		}
+		return parts[i].userID < parts[j].userID
+	})
+	for i := 0; i < left; i++ {
+		dst[parts[i].userID]++
+	}
 }
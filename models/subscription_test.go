@@ -0,0 +1,83 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sunr3d/subscription-aggregator/models"
+)
+
+func ptr(v int) *int { return &v }
+
+func TestShares_NoParticipants(t *testing.T) {
+	sub := models.Subscription{UserID: "owner", Price: 400}
+	require.Equal(t, map[string]int{"owner": 400}, sub.Shares())
+}
+
+func TestShares_Weights(t *testing.T) {
+	sub := models.Subscription{UserID: "owner", Price: 100, Participants: []models.Participant{
+		{UserID: "a", ShareWeight: ptr(1)},
+		{UserID: "b", ShareWeight: ptr(1)},
+		{UserID: "c", ShareWeight: ptr(1)},
+	}}
+	// 100 / 3: лишний рубль достаётся первому по user_id.
+	require.Equal(t, map[string]int{"a": 34, "b": 33, "c": 33}, sub.Shares())
+}
+
+func TestShares_FixedAndWeights(t *testing.T) {
+	sub := models.Subscription{UserID: "owner", Price: 1000, Participants: []models.Participant{
+		{UserID: "a", FixedAmount: ptr(100)},
+		{UserID: "b", ShareWeight: ptr(2)},
+		{UserID: "owner", ShareWeight: ptr(1)},
+	}}
+	require.Equal(t, map[string]int{"a": 100, "b": 600, "owner": 300}, sub.Shares())
+}
+
+func TestShares_FixedOnly_OwnerPaysRest(t *testing.T) {
+	sub := models.Subscription{UserID: "owner", Price: 500, Participants: []models.Participant{
+		{UserID: "a", FixedAmount: ptr(150)},
+	}}
+	require.Equal(t, map[string]int{"a": 150, "owner": 350}, sub.Shares())
+}
+
+func TestShares_FixedExceedsPrice(t *testing.T) {
+	sub := models.Subscription{UserID: "owner", Price: 300, Participants: []models.Participant{
+		{UserID: "a", FixedAmount: ptr(200)},
+		{UserID: "b", FixedAmount: ptr(400)},
+		{UserID: "c", ShareWeight: ptr(1)},
+	}}
+	require.Equal(t, map[string]int{"a": 100, "b": 200, "c": 0}, sub.Shares())
+}
+
+func TestSubscription_PriceAt(t *testing.T) {
+	month := func(m time.Month) time.Time { return time.Date(2025, m, 1, 0, 0, 0, 0, time.UTC) }
+	sub := models.Subscription{
+		Price: 400,
+		PriceChanges: []models.PriceChange{
+			{EffectiveFrom: month(time.March), Price: 500},
+			{EffectiveFrom: month(time.June), Price: 450},
+		},
+	}
+
+	require.Equal(t, 400, sub.PriceAt(month(time.February)))
+	require.Equal(t, 500, sub.PriceAt(month(time.March)))
+	require.Equal(t, 500, sub.PriceAt(month(time.May)))
+	require.Equal(t, 450, sub.PriceAt(month(time.December)))
+}
+
+func TestSubscription_PausedAt(t *testing.T) {
+	month := func(m time.Month) time.Time { return time.Date(2025, m, 1, 0, 0, 0, 0, time.UTC) }
+	end := month(time.April)
+	sub := models.Subscription{Pauses: []models.Pause{
+		{Start: month(time.March), End: &end},
+		{Start: month(time.October)},
+	}}
+
+	require.False(t, sub.PausedAt(month(time.February)))
+	require.True(t, sub.PausedAt(month(time.March)))
+	require.True(t, sub.PausedAt(month(time.April)))
+	require.False(t, sub.PausedAt(month(time.May)))
+	require.True(t, sub.PausedAt(month(time.December)))
+}